@@ -0,0 +1,140 @@
+// Command network-controller runs the Network/Endpoint CRD controllers
+// (pkg/controller) against a Kubernetes cluster, translating them into OVN
+// logical topology the same way the Docker plugin does for its own
+// networks/endpoints - sharing the same store datadir so both can recover
+// each other's state.
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/controller"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	networkv1alpha1 "github.com/ovs-container-lab/ovs-container-network/pkg/apis/network/v1alpha1"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovs"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store"
+)
+
+const defaultStoreURL = "bolt:///data/store.db"
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = networkv1alpha1.AddToScheme(scheme)
+}
+
+func main() {
+	var (
+		metricsAddr = flag.String("metrics-bind-address", ":8080", "Address the metrics endpoint binds to")
+		probeAddr   = flag.String("health-probe-bind-address", ":8081", "Address the health probe endpoint binds to")
+		leaderElect = flag.Bool("leader-elect", false, "Enable leader election, so only one controller instance is active at a time")
+		nbConn      = flag.String("ovn-nb-connection", "tcp:127.0.0.1:6641", "OVN Northbound connection string")
+		sbConn      = flag.String("ovn-sb-connection", "tcp:127.0.0.1:6642", "OVN Southbound connection string")
+		storeURL    = flag.String("store", envOrDefault("PLUGIN_STORE", defaultStoreURL),
+			"Persistent store, shared with the Docker plugin: bolt:///data/store.db, file:///data, etcd://host:2379, or consul://host:8500")
+	)
+	flag.Parse()
+
+	logger := logrus.New()
+
+	pluginStore, err := store.New(*storeURL, logger)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to initialize store")
+	}
+
+	ovnClient, err := ovn.NewClient(*nbConn, *sbConn)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to connect to OVN")
+	}
+	defer ovnClient.Disconnect()
+
+	ovsClient, err := ovs.NewClient()
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to connect to OVS")
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: *metricsAddr},
+		HealthProbeBindAddress: *probeAddr,
+		LeaderElection:         *leaderElect,
+		LeaderElectionID:       "ovs-container-network-network-controller",
+	})
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to create controller manager")
+	}
+
+	if err := (&controller.NetworkReconciler{
+		Client: mgr.GetClient(),
+		OVN:    ovnClient,
+		Store:  pluginStore,
+		Logger: logger,
+	}).SetupWithManager(mgr); err != nil {
+		logger.WithError(err).Fatal("Failed to set up Network controller")
+	}
+	if err := (&controller.EndpointReconciler{
+		Client: mgr.GetClient(),
+		OVN:    ovnClient,
+		Store:  pluginStore,
+		Logger: logger,
+	}).SetupWithManager(mgr); err != nil {
+		logger.WithError(err).Fatal("Failed to set up Endpoint controller")
+	}
+	if err := (&controller.ProviderNetworkReconciler{
+		Client: mgr.GetClient(),
+		OVS:    ovsClient,
+		Logger: logger,
+	}).SetupWithManager(mgr); err != nil {
+		logger.WithError(err).Fatal("Failed to set up ProviderNetwork controller")
+	}
+	if err := (&controller.LogicalRouterReconciler{
+		Client: mgr.GetClient(),
+		OVN:    ovnClient,
+		Logger: logger,
+	}).SetupWithManager(mgr); err != nil {
+		logger.WithError(err).Fatal("Failed to set up LogicalRouter controller")
+	}
+	if err := (&controller.VLANAttachmentReconciler{
+		Client: mgr.GetClient(),
+		OVN:    ovnClient,
+		Logger: logger,
+	}).SetupWithManager(mgr); err != nil {
+		logger.WithError(err).Fatal("Failed to set up VLANAttachment controller")
+	}
+	if err := (&controller.NetworkPolicyReconciler{
+		Client: mgr.GetClient(),
+		OVN:    ovnClient,
+		Logger: logger,
+	}).SetupWithManager(mgr); err != nil {
+		logger.WithError(err).Fatal("Failed to set up NetworkPolicy controller")
+	}
+	if err := (&controller.PodReconciler{
+		Client: mgr.GetClient(),
+		Logger: logger,
+	}).SetupWithManager(mgr); err != nil {
+		logger.WithError(err).Fatal("Failed to set up Pod controller")
+	}
+
+	logger.Info("Starting network-controller")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		logger.WithError(err).Fatal("Controller manager exited with an error")
+	}
+	os.Exit(0)
+}
+
+// envOrDefault returns the named environment variable's value, or fallback
+// if it's unset, matching main.go's own helper of the same name.
+func envOrDefault(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
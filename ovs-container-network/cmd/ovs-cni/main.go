@@ -0,0 +1,142 @@
+// Command ovs-cni is the CNI v1.0 shim the kubelet execs per pod. It parses
+// the CNI network config and environment the kubelet gives it, forwards
+// ADD/DEL/CHECK over a local Unix socket to the long-running plugin
+// process's CNI server (pkg/cniserver), and prints the CNI result JSON it
+// gets back. All the actual OVS/OVN work happens in the plugin process,
+// against the same Driver the Docker dnetwork handler uses.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/cniserver"
+)
+
+const defaultCNISocketPath = "/run/ovs-cni.sock"
+
+// netConf is the CNI network config read from stdin. Annotations lets a
+// pod override its network/switch/router/static addressing the same way a
+// Docker container does via driver options - see pkg/cniserver.Request.
+type netConf struct {
+	types.NetConf
+	NetworkName string            `json:"networkName"`
+	SocketPath  string            `json:"socketPath"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func parseConf(stdin []byte) (*netConf, error) {
+	conf := &netConf{}
+	if err := json.Unmarshal(stdin, conf); err != nil {
+		return nil, fmt.Errorf("failed to parse network config: %w", err)
+	}
+	if conf.SocketPath == "" {
+		conf.SocketPath = defaultCNISocketPath
+	}
+	return conf, nil
+}
+
+func call(socketPath string, req *cniserver.Request) (*cniserver.Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode CNI request: %w", err)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(_ context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", socketPath)
+			},
+		},
+	}
+
+	httpResp, err := client.Post("http://unix/cmd", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach ovs-cni server at %s: %w", socketPath, err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp cniserver.Response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode ovs-cni server response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("ovs-cni server: %s", resp.Error)
+	}
+	return &resp, nil
+}
+
+func requestFromArgs(command string, args *skel.CmdArgs, conf *netConf) *cniserver.Request {
+	return &cniserver.Request{
+		Command:     command,
+		ContainerID: args.ContainerID,
+		NetworkName: conf.NetworkName,
+		Netns:       args.Netns,
+		IfName:      args.IfName,
+		Annotations: conf.Annotations,
+	}
+}
+
+func cmdAdd(args *skel.CmdArgs) error {
+	conf, err := parseConf(args.StdinData)
+	if err != nil {
+		return err
+	}
+
+	resp, err := call(conf.SocketPath, requestFromArgs("ADD", args, conf))
+	if err != nil {
+		return err
+	}
+
+	result := &current.Result{CNIVersion: current.ImplementedSpecVersion}
+	if resp.IPv4Address != "" {
+		if ip, ipNet, err := net.ParseCIDR(resp.IPv4Address); err == nil {
+			result.IPs = append(result.IPs, &current.IPConfig{
+				Address: net.IPNet{IP: ip, Mask: ipNet.Mask},
+				Gateway: net.ParseIP(resp.Gateway),
+			})
+		}
+	}
+	if resp.IPv6Address != "" {
+		if ip, ipNet, err := net.ParseCIDR(resp.IPv6Address); err == nil {
+			result.IPs = append(result.IPs, &current.IPConfig{
+				Address: net.IPNet{IP: ip, Mask: ipNet.Mask},
+				Gateway: net.ParseIP(resp.GatewayIPv6),
+			})
+		}
+	}
+
+	return types.PrintResult(result, conf.CNIVersion)
+}
+
+func cmdDel(args *skel.CmdArgs) error {
+	conf, err := parseConf(args.StdinData)
+	if err != nil {
+		return err
+	}
+	_, err = call(conf.SocketPath, requestFromArgs("DEL", args, conf))
+	return err
+}
+
+func cmdCheck(args *skel.CmdArgs) error {
+	conf, err := parseConf(args.StdinData)
+	if err != nil {
+		return err
+	}
+	_, err = call(conf.SocketPath, requestFromArgs("CHECK", args, conf))
+	return err
+}
+
+func main() {
+	skel.PluginMainFuncs(skel.CNIFuncs{Add: cmdAdd, Check: cmdCheck, Del: cmdDel}, version.All,
+		"ovs-cni: OVS/OVN CNI shim for ovs-container-network")
+}
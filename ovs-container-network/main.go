@@ -1,26 +1,41 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/docker/go-plugins-helpers/network"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/cniserver"
 	"github.com/ovs-container-lab/ovs-container-network/pkg/driver"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/driver/policy"
 	"github.com/sirupsen/logrus"
 )
 
 const (
-	defaultSocketPath = "/run/docker/plugins/ovs-net.sock"
-	pluginName        = "ovs-container-network"
-	pluginVersion     = "0.1.0"
+	defaultSocketPath    = "/run/docker/plugins/ovs-net.sock"
+	defaultCNISocketPath = "/run/ovs-cni.sock"
+	defaultStoreURL      = "bolt:///data/store.db"
+	defaultACLLogPath    = "/var/log/ovn/acl-log.log"
+	pluginName           = "ovs-container-network"
+	pluginVersion        = "0.1.0"
 )
 
 func main() {
 	var (
-		socketPath = flag.String("socket", defaultSocketPath, "Plugin socket path")
-		debugMode  = flag.Bool("debug", false, "Enable debug logging")
-		version    = flag.Bool("version", false, "Print version and exit")
+		socketPath    = flag.String("socket", defaultSocketPath, "Plugin socket path")
+		debugMode     = flag.Bool("debug", false, "Enable debug logging")
+		version       = flag.Bool("version", false, "Print version and exit")
+		enableCNI     = flag.Bool("cni", false, "Also serve CNI ADD/DEL/CHECK on -cni-socket, for mixed Docker + Kubernetes nodes")
+		cniSocketPath = flag.String("cni-socket", defaultCNISocketPath, "CNI server socket path")
+		storeURL      = flag.String("store", envOrDefault("PLUGIN_STORE", defaultStoreURL),
+			"Persistent store: bolt:///data/store.db, file:///data, etcd://host:2379, or consul://host:8500")
+		aclLogPath = flag.String("acl-log", envOrDefault("PLUGIN_ACL_LOG", defaultACLLogPath),
+			"ACL log file tailed by GET /policy/log")
 	)
 	flag.Parse()
 
@@ -43,14 +58,46 @@ func main() {
 	logrus.Debugf("Socket path: %s", *socketPath)
 
 	// Create the driver
-	d, err := driver.New()
+	d, err := driver.New(*storeURL)
 	if err != nil {
 		logrus.Fatalf("Failed to create driver: %v", err)
 	}
 
+	// Serve CNI ADD/DEL/CHECK on its own socket, translated onto the same
+	// Driver, so a node can run Docker and Kubernetes workloads on one OVN
+	// fabric without a second control plane.
+	if *enableCNI {
+		if err := cniserver.WriteConflist(cniserver.DefaultConflistPath, d.ListNetworks(), *cniSocketPath); err != nil {
+			logrus.Warnf("Failed to write CNI conflist: %v", err)
+		}
+
+		cniSrv := cniserver.NewServer(d, logrus.StandardLogger())
+		go func() {
+			if err := cniSrv.ListenAndServe(*cniSocketPath); err != nil {
+				logrus.Fatalf("Failed to serve CNI: %v", err)
+			}
+		}()
+	}
+
 	// Create the plugin handler
 	h := network.NewHandler(d)
 
+	// Expose a small admin API for elastic IPs on the same socket - "ovn.eip"
+	// on CreateEndpoint covers the common case, but binding one to an
+	// already-running endpoint, or freeing one outright, needs an explicit call.
+	h.HandleFunc("/eip", func(w http.ResponseWriter, r *http.Request) { handleCreateEIP(d, w, r) })
+	h.HandleFunc("/eip/", func(w http.ResponseWriter, r *http.Request) { handleDeleteEIP(d, w, r) })
+
+	// Expose a read-only inspection endpoint for "ovn.lb.<name>" load
+	// balancers, for debugging which VIPs/backends are currently live
+	// without shelling into ovn-nbctl by hand.
+	h.HandleFunc("/lb", func(w http.ResponseWriter, r *http.Request) { handleListLBs(d, w, r) })
+
+	// Expose a read-only tail of the microsegmentation ACL log (see
+	// pkg/driver/policy), so "ovn.policy" rules with "log" set can be
+	// inspected without shelling in to read the log file directly.
+	h.HandleFunc("/policy/log", func(w http.ResponseWriter, r *http.Request) { handlePolicyLog(*aclLogPath, w, r) })
+
 	// Ensure the socket directory exists
 	if err := os.MkdirAll("/run/docker/plugins", 0755); err != nil {
 		logrus.Fatalf("Failed to create plugin directory: %v", err)
@@ -65,3 +112,101 @@ func main() {
 		logrus.Fatalf("Failed to serve: %v", err)
 	}
 }
+
+// createEIPRequest is the POST /eip admin API's request body.
+type createEIPRequest struct {
+	NetworkID  string `json:"network_id"`
+	EndpointID string `json:"endpoint_id"`
+	CIDR       string `json:"cidr"`
+}
+
+func handleCreateEIP(d *driver.Driver, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req createEIPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	eipInfo, err := d.CreateEIP(req.NetworkID, req.EndpointID, req.CIDR)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(eipInfo)
+}
+
+func handleDeleteEIP(d *driver.Driver, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	eipID := strings.TrimPrefix(r.URL.Path, "/eip/")
+	if eipID == "" {
+		http.Error(w, "missing elastic IP id", http.StatusBadRequest)
+		return
+	}
+
+	if err := d.DeleteEIP(eipID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListLBs serves GET /lb[?network_id=...], listing every declared
+// load balancer's current VIP/backend state for debugging.
+func handleListLBs(d *driver.Driver, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	lbs := d.ListLoadBalancers(r.URL.Query().Get("network_id"))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lbs)
+}
+
+// handlePolicyLog serves GET /policy/log[?lines=N], tailing the ACL log file
+// configured via -acl-log.
+func handlePolicyLog(aclLogPath string, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	lines := 0
+	if raw := r.URL.Query().Get("lines"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil {
+			lines = n
+		}
+	}
+
+	entries, err := policy.TailLog(aclLogPath, lines)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// envOrDefault returns the named environment variable's value, or fallback
+// if it's unset, so --store can be overridden by PLUGIN_STORE without
+// shadowing an explicit flag value.
+func envOrDefault(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,51 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EndpointFinalizer is set on every Endpoint while its OVN logical port
+// exists, so deletion waits for the controller to tear it down.
+const EndpointFinalizer = "ovs-container-lab/endpoint-protection"
+
+// EndpointSpec requests a logical port on an existing Network.
+type EndpointSpec struct {
+	// NetworkRef is the name of the Network this endpoint's port belongs to.
+	NetworkRef string `json:"networkRef"`
+	// MACAddress is set, together with IPAddress, as the port's addresses
+	// and port security when both are given. If either is empty the port
+	// gets no addresses, and no MAC is generated for it.
+	MACAddress string `json:"macAddress,omitempty"`
+	// IPAddress is the port's address with prefix, e.g. "10.0.1.5/24".
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// EndpointStatus reports the logical port the controller created.
+type EndpointStatus struct {
+	// OVNPort is the logical switch port name, matching NetworkRef's switch.
+	OVNPort string `json:"ovnPort,omitempty"`
+	// Conditions reflects reconciliation progress; Ready mirrors Network's.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Endpoint is the Schema for the endpoints API: a logical port request
+// against a Network, reconciled by pkg/controller.EndpointReconciler.
+type Endpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EndpointSpec   `json:"spec,omitempty"`
+	Status EndpointStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EndpointList contains a list of Endpoint.
+type EndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Endpoint `json:"items"`
+}
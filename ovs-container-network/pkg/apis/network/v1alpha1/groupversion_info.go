@@ -0,0 +1,31 @@
+// Package v1alpha1 contains the Network and Endpoint CRD types that
+// pkg/controller reconciles into OVN logical topology via ovn.Client,
+// mirroring the Network-CRD-plus-controller pattern used by
+// ovn4nfv-k8s-plugin.
+// +kubebuilder:object:generate=true
+// +groupName=network.ovs-container-lab.io
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is the API group and version used for every type in this package.
+	GroupVersion = schema.GroupVersion{Group: "network.ovs-container-lab.io", Version: "v1alpha1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func init() {
+	SchemeBuilder.Register(&Network{}, &NetworkList{}, &Endpoint{}, &EndpointList{},
+		&ProviderNetwork{}, &ProviderNetworkList{},
+		&LogicalRouter{}, &LogicalRouterList{},
+		&VLANAttachment{}, &VLANAttachmentList{},
+		&NetworkPolicy{}, &NetworkPolicyList{})
+}
@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LogicalRouterFinalizer is set on every LogicalRouter while its OVN logical
+// router exists, so deletion waits for the controller to tear it down.
+const LogicalRouterFinalizer = "ovs-container-lab/logical-router-protection"
+
+// ConditionOVNRouterCreated reports whether Spec has been reflected in an
+// OVN Logical_Router.
+const ConditionOVNRouterCreated = "OVNRouterCreated"
+
+// LogicalRouterSpec describes a standalone OVN logical router, for topologies
+// that need a router not owned by any single Network (e.g. a router
+// connecting several Networks together, or carrying SNAT for north-south
+// traffic) - where Network.Spec's own router is implicit and scoped to that
+// one network, LogicalRouter is explicit and shared.
+type LogicalRouterSpec struct {
+	// Name is the logical router name; defaults to the LogicalRouter's own
+	// name if empty.
+	Name string `json:"name,omitempty"`
+	// StaticRoutes are installed via ovn.Client.AddStaticRoute.
+	StaticRoutes []Route `json:"staticRoutes,omitempty"`
+	// SNAT rules are installed via ovn.Client.AddSNAT.
+	SNAT []SNATRule `json:"snat,omitempty"`
+}
+
+// SNATRule source-NATs traffic leaving Subnet to ExternalIP.
+type SNATRule struct {
+	Subnet     string `json:"subnet"`
+	ExternalIP string `json:"externalIP"`
+}
+
+// LogicalRouterStatus reports what the controller has actually reconciled.
+type LogicalRouterStatus struct {
+	// OVNRouter is the logical router name the controller created.
+	OVNRouter string `json:"ovnRouter,omitempty"`
+	// Conditions reflects reconciliation progress: Ready, OVNRouterCreated.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// LogicalRouter is the Schema for the logicalrouters API: a standalone OVN
+// logical router with static routes and SNAT, reconciled by
+// pkg/controller.LogicalRouterReconciler.
+type LogicalRouter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LogicalRouterSpec   `json:"spec,omitempty"`
+	Status LogicalRouterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LogicalRouterList contains a list of LogicalRouter.
+type LogicalRouterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LogicalRouter `json:"items"`
+}
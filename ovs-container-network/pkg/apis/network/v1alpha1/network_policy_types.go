@@ -0,0 +1,73 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkPolicyFinalizer is set on every NetworkPolicy while its ACLs exist
+// in the referenced network's port group, so deletion waits for the
+// controller to tear them down.
+const NetworkPolicyFinalizer = "ovs-container-lab/network-policy-protection"
+
+// ConditionPolicyApplied reports whether Spec.Rules is currently reflected in
+// OVN ACLs.
+const ConditionPolicyApplied = "PolicyApplied"
+
+// NetworkPolicySpec is the CRD form of the rule list the Docker driver
+// accepts as JSON in a network's "ovn.policy" option (see
+// pkg/driver/policy.Rule) - PolicyRule mirrors that type field-for-field
+// rather than importing pkg/driver/policy directly, keeping this API package
+// free of a dependency on driver internals.
+type NetworkPolicySpec struct {
+	// NetworkRef is the name of the Network these rules apply to.
+	NetworkRef string `json:"networkRef"`
+	// Rules are compiled into ACLs on NetworkRef's network-wide port group.
+	Rules []PolicyRule `json:"rules,omitempty"`
+}
+
+// PolicyRule is one microsegmentation rule; see pkg/driver/policy.Rule for
+// the field-by-field semantics this mirrors.
+type PolicyRule struct {
+	Direction string `json:"direction"`
+	Selector  string `json:"selector,omitempty"`
+	CIDR      string `json:"cidr,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	PortMin   int    `json:"portMin,omitempty"`
+	PortMax   int    `json:"portMax,omitempty"`
+	CtState   string `json:"ctState,omitempty"`
+	Action    string `json:"action"`
+	Priority  int    `json:"priority"`
+	Log       bool   `json:"log,omitempty"`
+}
+
+// NetworkPolicyStatus reports what the controller has actually applied.
+type NetworkPolicyStatus struct {
+	// AppliedHash is the digest (see pkg/driver/policy.Hash) of the rule set
+	// last programmed into OVN, used to skip reprogramming unchanged rules.
+	AppliedHash string `json:"appliedHash,omitempty"`
+	// Conditions reflects reconciliation progress: Ready, PolicyApplied.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// NetworkPolicy is the Schema for the networkpolicies API: a
+// microsegmentation rule set applied to a Network's endpoints, reconciled by
+// pkg/controller.NetworkPolicyReconciler.
+type NetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkPolicySpec   `json:"spec,omitempty"`
+	Status NetworkPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NetworkPolicyList contains a list of NetworkPolicy.
+type NetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkPolicy `json:"items"`
+}
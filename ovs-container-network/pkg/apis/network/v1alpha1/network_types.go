@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkFinalizer is set on every Network while its OVN logical switch and
+// router exist, so the API server blocks deletion until the controller has
+// torn them down.
+const NetworkFinalizer = "ovs-container-lab/network-protection"
+
+// Condition types reported in Network.Status.Conditions.
+const (
+	ConditionReady            = "Ready"
+	ConditionOVNSwitchCreated = "OVNSwitchCreated"
+	ConditionDHCPConfigured   = "DHCPConfigured"
+)
+
+// NetworkSpec is the desired logical topology for one tenant network: an
+// OVN logical switch, optionally routed and DHCP-enabled.
+type NetworkSpec struct {
+	// Name is the logical switch name; defaults to the Network's own name
+	// if empty.
+	Name string `json:"name,omitempty"`
+	// Subnet is the network's CIDR, e.g. "10.0.1.0/24".
+	Subnet string `json:"subnet"`
+	// Gateway is the subnet's gateway address, handed out as the DHCP
+	// "router" option; setting it also creates the network's logical
+	// router. No logical router port is created with this address.
+	Gateway string `json:"gateway,omitempty"`
+	// VLAN tags traffic on this network's bridge, 0 for none.
+	VLAN int `json:"vlan,omitempty"`
+	// TenantID is recorded as an external_id on the OVN switch and router
+	// for multi-tenant filtering, matching the Docker driver's ovn.tenant_id
+	// option. Endpoint ports do not carry it.
+	TenantID string `json:"tenantID,omitempty"`
+	// Routes are static routes installed on the network's logical router;
+	// they are only installed when Gateway is set. No default route via
+	// Gateway is added.
+	Routes []Route `json:"routes,omitempty"`
+	// DNSServers feed the network's DHCP options; only the first entry is
+	// handed out, as the "dns_server" option.
+	DNSServers []string `json:"dnsServers,omitempty"`
+}
+
+// Route is a static route destined for the network's logical router.
+type Route struct {
+	Destination string `json:"destination"`
+	NextHop     string `json:"nextHop"`
+}
+
+// NetworkStatus reports what the controller has actually reconciled.
+type NetworkStatus struct {
+	// OVNSwitch is the logical switch name the controller created.
+	OVNSwitch string `json:"ovnSwitch,omitempty"`
+	// OVNRouter is the logical router name, empty if no routing was configured.
+	OVNRouter string `json:"ovnRouter,omitempty"`
+	// DHCPOptionsUUID is the OVN DHCP_Options row backing this network, if any.
+	DHCPOptionsUUID string `json:"dhcpOptionsUUID,omitempty"`
+	// Conditions reflects reconciliation progress: Ready, OVNSwitchCreated, DHCPConfigured.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Network is the Schema for the networks API: a tenant-facing description
+// of an OVN logical switch, reconciled by pkg/controller.NetworkReconciler.
+type Network struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkSpec   `json:"spec,omitempty"`
+	Status NetworkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NetworkList contains a list of Network.
+type NetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Network `json:"items"`
+}
@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types reported in ProviderNetwork.Status.Conditions.
+const ConditionBridgeMappingPresent = "BridgeMappingPresent"
+
+// ProviderNetworkSpec names a physical network this node's OVS is bridged
+// to, the same "physnet" concept Network's "ovn.physical_network"
+// u2o-interconnection option (see pkg/driver's isU2O/setupU2OInterconnection)
+// and VLANAttachment both reference by name.
+type ProviderNetworkSpec struct {
+	// PhysicalNetwork is the physnet name, matched against this node's
+	// "external_ids:ovn-bridge-mappings" - e.g. "physnet1".
+	PhysicalNetwork string `json:"physicalNetwork"`
+	// Bridge is the OVS bridge PhysicalNetwork is expected to be mapped to,
+	// e.g. "br-provider".
+	Bridge string `json:"bridge"`
+	// VLAN tags traffic reaching this provider network, 0 for untagged.
+	VLAN int `json:"vlan,omitempty"`
+}
+
+// ProviderNetworkStatus reports whether this node's chassis actually has the
+// bridge mapping PhysicalNetwork requires. Unlike Network/Endpoint, nothing
+// here is created by the controller - an OVS bridge mapping is chassis-level
+// host configuration (ovs-vsctl set open_vswitch . external_ids:ovn-bridge-mappings),
+// not an OVN Northbound object - so this is read-only status surfacing, not
+// reconciliation.
+type ProviderNetworkStatus struct {
+	// Conditions reflects whether BridgeMappingPresent was last observed true.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ProviderNetwork is the Schema for the providernetworks API: a declared
+// physical network mapping, reconciled (read-only) by
+// pkg/controller.ProviderNetworkReconciler, and referenced by name from
+// VLANAttachment.
+type ProviderNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderNetworkSpec   `json:"spec,omitempty"`
+	Status ProviderNetworkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderNetworkList contains a list of ProviderNetwork.
+type ProviderNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderNetwork `json:"items"`
+}
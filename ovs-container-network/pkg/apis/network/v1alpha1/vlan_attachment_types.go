@@ -0,0 +1,59 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VLANAttachmentFinalizer is set on every VLANAttachment while its localnet
+// port exists, so deletion waits for the controller to tear it down.
+const VLANAttachmentFinalizer = "ovs-container-lab/vlan-attachment-protection"
+
+// ConditionLocalnetPortCreated reports whether the localnet port backing
+// this attachment exists.
+const ConditionLocalnetPortCreated = "LocalnetPortCreated"
+
+// VLANAttachmentSpec attaches NetworkRef's logical switch to
+// ProviderNetworkRef's physical network over a tagged localnet port, the
+// same type=localnet port pattern pkg/driver's u2o interconnection
+// (setupU2OInterconnection) uses for its own provider-network uplink, here
+// exposed as a standalone CRD rather than an implicit side effect of a
+// Network's "ovn.physical_network" option.
+type VLANAttachmentSpec struct {
+	// NetworkRef is the Network whose logical switch gets the localnet port.
+	NetworkRef string `json:"networkRef"`
+	// ProviderNetworkRef is the ProviderNetwork supplying the physnet name.
+	ProviderNetworkRef string `json:"providerNetworkRef"`
+	// VLAN tags the localnet port, 0 for untagged.
+	VLAN int `json:"vlan,omitempty"`
+}
+
+// VLANAttachmentStatus reports what the controller has actually reconciled.
+type VLANAttachmentStatus struct {
+	// OVNPort is the localnet logical switch port name the controller created.
+	OVNPort string `json:"ovnPort,omitempty"`
+	// Conditions reflects reconciliation progress: Ready, LocalnetPortCreated.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// VLANAttachment is the Schema for the vlanattachments API: a tagged
+// localnet port joining a Network to a ProviderNetwork, reconciled by
+// pkg/controller.VLANAttachmentReconciler.
+type VLANAttachment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VLANAttachmentSpec   `json:"spec,omitempty"`
+	Status VLANAttachmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VLANAttachmentList contains a list of VLANAttachment.
+type VLANAttachmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VLANAttachment `json:"items"`
+}
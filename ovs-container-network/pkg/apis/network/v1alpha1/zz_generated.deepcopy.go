@@ -0,0 +1,639 @@
+// Code generated by controller-gen would normally populate this file; it is
+// hand-written here since this module has no code-generation step wired up.
+// Keep it in sync with network_types.go/endpoint_types.go by hand.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies all fields into out.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+}
+
+// DeepCopy returns a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	if in.Routes != nil {
+		out.Routes = make([]Route, len(in.Routes))
+		copy(out.Routes, in.Routes)
+	}
+	if in.DNSServers != nil {
+		out.DNSServers = make([]string, len(in.DNSServers))
+		copy(out.DNSServers, in.DNSServers)
+	}
+}
+
+// DeepCopy returns a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+// DeepCopy returns a new NetworkStatus.
+func (in *NetworkStatus) DeepCopy() *NetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *Network) DeepCopyInto(out *Network) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a new Network.
+func (in *Network) DeepCopy() *Network {
+	if in == nil {
+		return nil
+	}
+	out := new(Network)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *Network) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *NetworkList) DeepCopyInto(out *NetworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]Network, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a new NetworkList.
+func (in *NetworkList) DeepCopy() *NetworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *NetworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *EndpointSpec) DeepCopyInto(out *EndpointSpec) {
+	*out = *in
+}
+
+// DeepCopy returns a new EndpointSpec.
+func (in *EndpointSpec) DeepCopy() *EndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+// DeepCopy returns a new EndpointStatus.
+func (in *EndpointStatus) DeepCopy() *EndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *Endpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *EndpointList) DeepCopyInto(out *EndpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]Endpoint, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a new EndpointList.
+func (in *EndpointList) DeepCopy() *EndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *EndpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *ProviderNetworkSpec) DeepCopyInto(out *ProviderNetworkSpec) {
+	*out = *in
+}
+
+// DeepCopy returns a new ProviderNetworkSpec.
+func (in *ProviderNetworkSpec) DeepCopy() *ProviderNetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderNetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *ProviderNetworkStatus) DeepCopyInto(out *ProviderNetworkStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+// DeepCopy returns a new ProviderNetworkStatus.
+func (in *ProviderNetworkStatus) DeepCopy() *ProviderNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *ProviderNetwork) DeepCopyInto(out *ProviderNetwork) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a new ProviderNetwork.
+func (in *ProviderNetwork) DeepCopy() *ProviderNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *ProviderNetwork) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *ProviderNetworkList) DeepCopyInto(out *ProviderNetworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]ProviderNetwork, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a new ProviderNetworkList.
+func (in *ProviderNetworkList) DeepCopy() *ProviderNetworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderNetworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *ProviderNetworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *SNATRule) DeepCopyInto(out *SNATRule) {
+	*out = *in
+}
+
+// DeepCopy returns a new SNATRule.
+func (in *SNATRule) DeepCopy() *SNATRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *LogicalRouterSpec) DeepCopyInto(out *LogicalRouterSpec) {
+	*out = *in
+	if in.StaticRoutes != nil {
+		out.StaticRoutes = make([]Route, len(in.StaticRoutes))
+		copy(out.StaticRoutes, in.StaticRoutes)
+	}
+	if in.SNAT != nil {
+		out.SNAT = make([]SNATRule, len(in.SNAT))
+		copy(out.SNAT, in.SNAT)
+	}
+}
+
+// DeepCopy returns a new LogicalRouterSpec.
+func (in *LogicalRouterSpec) DeepCopy() *LogicalRouterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalRouterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *LogicalRouterStatus) DeepCopyInto(out *LogicalRouterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+// DeepCopy returns a new LogicalRouterStatus.
+func (in *LogicalRouterStatus) DeepCopy() *LogicalRouterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalRouterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *LogicalRouter) DeepCopyInto(out *LogicalRouter) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a new LogicalRouter.
+func (in *LogicalRouter) DeepCopy() *LogicalRouter {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalRouter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *LogicalRouter) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *LogicalRouterList) DeepCopyInto(out *LogicalRouterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]LogicalRouter, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a new LogicalRouterList.
+func (in *LogicalRouterList) DeepCopy() *LogicalRouterList {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalRouterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *LogicalRouterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *VLANAttachmentSpec) DeepCopyInto(out *VLANAttachmentSpec) {
+	*out = *in
+}
+
+// DeepCopy returns a new VLANAttachmentSpec.
+func (in *VLANAttachmentSpec) DeepCopy() *VLANAttachmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VLANAttachmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *VLANAttachmentStatus) DeepCopyInto(out *VLANAttachmentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+// DeepCopy returns a new VLANAttachmentStatus.
+func (in *VLANAttachmentStatus) DeepCopy() *VLANAttachmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VLANAttachmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *VLANAttachment) DeepCopyInto(out *VLANAttachment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a new VLANAttachment.
+func (in *VLANAttachment) DeepCopy() *VLANAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(VLANAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *VLANAttachment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *VLANAttachmentList) DeepCopyInto(out *VLANAttachmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]VLANAttachment, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a new VLANAttachmentList.
+func (in *VLANAttachmentList) DeepCopy() *VLANAttachmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(VLANAttachmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *VLANAttachmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *PolicyRule) DeepCopyInto(out *PolicyRule) {
+	*out = *in
+}
+
+// DeepCopy returns a new PolicyRule.
+func (in *PolicyRule) DeepCopy() *PolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+	if in.Rules != nil {
+		out.Rules = make([]PolicyRule, len(in.Rules))
+		copy(out.Rules, in.Rules)
+	}
+}
+
+// DeepCopy returns a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *NetworkPolicyStatus) DeepCopyInto(out *NetworkPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+// DeepCopy returns a new NetworkPolicyStatus.
+func (in *NetworkPolicyStatus) DeepCopy() *NetworkPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *NetworkPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies all fields into out.
+func (in *NetworkPolicyList) DeepCopyInto(out *NetworkPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]NetworkPolicy, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a new NetworkPolicyList.
+func (in *NetworkPolicyList) DeepCopy() *NetworkPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *NetworkPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
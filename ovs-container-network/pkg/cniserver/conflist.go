@@ -0,0 +1,68 @@
+package cniserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store"
+)
+
+// DefaultConflistPath is where kubelet looks for CNI network configuration
+// by default.
+const DefaultConflistPath = "/etc/cni/net.d/10-ovs.conflist"
+
+// confList mirrors the CNI conflist JSON shape.
+type confList struct {
+	CNIVersion string       `json:"cniVersion"`
+	Name       string       `json:"name"`
+	Plugins    []pluginConf `json:"plugins"`
+}
+
+type pluginConf struct {
+	Type           string `json:"type"`
+	SocketPath     string `json:"socketPath"`
+	DefaultNetwork string `json:"networkName"`
+}
+
+// WriteConflist writes a conflist at path pointing at the CNI server's
+// socket, defaulting pods with no ovn.switch/ovn.router annotation onto the
+// first network known to the store. Kubernetes CNI has no equivalent to
+// Docker's per-container "docker network connect <name>"; a pod's network
+// is picked once, from the conflist, when the kubelet invokes ADD - so for
+// a node serving more than one network, per-pod annotations (see
+// pkg/cniserver.Request) are the only way to pick something other than the
+// default. Called once at startup, after recoverState has populated the
+// store.
+func WriteConflist(path string, networks []*store.NetworkInfo, socketPath string) error {
+	if len(networks) == 0 {
+		return nil
+	}
+
+	cl := confList{
+		CNIVersion: "1.0.0",
+		Name:       "ovs-cni",
+		Plugins: []pluginConf{
+			{
+				Type:           "ovs-cni",
+				SocketPath:     socketPath,
+				DefaultNetwork: networks[0].ID,
+			},
+		},
+	}
+
+	data, err := json.MarshalIndent(&cl, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal CNI conflist: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create CNI config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write CNI conflist %s: %w", path, err)
+	}
+
+	return nil
+}
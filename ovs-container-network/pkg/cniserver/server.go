@@ -0,0 +1,204 @@
+// Package cniserver implements a small CNI v1.0 shim for the plugin: an
+// HTTP handler, listening on its own Unix socket alongside the Docker
+// dnetwork handler in main.go, that lets Kubernetes (via cmd/ovs-cni) and
+// Docker share one OVS/OVN-backed Driver and one set of logical switches/
+// routers instead of running a second control plane.
+//
+// This mirrors the cnishim/cniserver split used by ovn4nfv-k8s-plugin: the
+// cmd/ovs-cni binary is the thin shim the kubelet execs per pod, and it just
+// forwards the CNI command over this socket to the long-running plugin
+// process, which does the actual OVS/OVN work.
+package cniserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	dnetwork "github.com/docker/go-plugins-helpers/network"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/driver"
+	"github.com/sirupsen/logrus"
+)
+
+// Request is the wire format cmd/ovs-cni sends for each CNI command.
+// Annotations carries the pod annotations the shim read from the network
+// config/runtime, using the same keys Docker would pass as driver options:
+// ovn.switch, ovn.router, tenant_id, ovn.static_ip, ovn.static_mac.
+type Request struct {
+	Command     string            `json:"command"` // "ADD", "DEL", or "CHECK"
+	ContainerID string            `json:"container_id"`
+	NetworkName string            `json:"network_name"` // Docker network ID/name this pod attaches to
+	Netns       string            `json:"netns"`
+	IfName      string            `json:"if_name"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Response carries back what cmd/ovs-cni needs to build its CNI result
+// JSON. Error is set (with everything else left zero) on failure.
+type Response struct {
+	IPv4Address string `json:"ipv4_address,omitempty"`
+	IPv6Address string `json:"ipv6_address,omitempty"`
+	Gateway     string `json:"gateway,omitempty"`
+	GatewayIPv6 string `json:"gateway_ipv6,omitempty"`
+	MacAddress  string `json:"mac_address,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Server translates CNI ADD/DEL/CHECK into the same CreateEndpoint/Join/
+// Leave/DeleteEndpoint/EndpointInfo calls the Docker dnetwork handler makes,
+// against the same Driver, so Docker and Kubernetes workloads share one
+// in-memory/OVN state under one lock.
+type Server struct {
+	driver *driver.Driver
+	logger *logrus.Logger
+}
+
+// NewServer wraps d for CNI use.
+func NewServer(d *driver.Driver, logger *logrus.Logger) *Server {
+	return &Server{driver: d, logger: logger}
+}
+
+// ListenAndServe removes any stale socket at socketPath and serves CNI
+// requests on it until an error occurs, mirroring how main.go serves the
+// Docker plugin socket.
+func (s *Server) ListenAndServe(socketPath string) error {
+	os.Remove(socketPath)
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cmd", s.handleCmd)
+
+	s.logger.Infof("CNI server listening on %s", socketPath)
+	return http.Serve(listener, mux)
+}
+
+func (s *Server) handleCmd(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, fmt.Errorf("failed to decode CNI request: %w", err))
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"command":      req.Command,
+		"container_id": req.ContainerID,
+		"network":      req.NetworkName,
+	}).Info("CNI request")
+
+	var (
+		resp *Response
+		err  error
+	)
+	switch req.Command {
+	case "ADD":
+		resp, err = s.add(&req)
+	case "DEL":
+		err = s.del(&req)
+		resp = &Response{}
+	case "CHECK":
+		resp, err = s.check(&req)
+	default:
+		err = fmt.Errorf("unknown CNI command %q", req.Command)
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(&Response{Error: err.Error()})
+}
+
+// optionsFromAnnotations maps the pod annotations cmd/ovs-cni forwards onto
+// the same option keys Docker driver options use, so CreateEndpoint's
+// per-endpoint switch/router/tenant override applies the same way no matter
+// which caller set them.
+func optionsFromAnnotations(annotations map[string]string) map[string]interface{} {
+	opts := make(map[string]interface{}, len(annotations))
+	for _, key := range []string{"ovn.switch", "ovn.router", "tenant_id"} {
+		if v := annotations[key]; v != "" {
+			opts[key] = v
+		}
+	}
+	return opts
+}
+
+func (s *Server) add(req *Request) (*Response, error) {
+	iface := &dnetwork.EndpointInterface{
+		MacAddress: req.Annotations["ovn.static_mac"],
+		Address:    req.Annotations["ovn.static_ip"],
+	}
+
+	if _, err := s.driver.CreateEndpoint(&dnetwork.CreateEndpointRequest{
+		NetworkID:  req.NetworkName,
+		EndpointID: req.ContainerID,
+		Interface:  iface,
+		Options:    optionsFromAnnotations(req.Annotations),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	joinResp, err := s.driver.Join(&dnetwork.JoinRequest{
+		NetworkID:  req.NetworkName,
+		EndpointID: req.ContainerID,
+		SandboxKey: req.Netns,
+	})
+	if err != nil {
+		s.driver.DeleteEndpoint(&dnetwork.DeleteEndpointRequest{NetworkID: req.NetworkName, EndpointID: req.ContainerID})
+		return nil, fmt.Errorf("failed to join network: %w", err)
+	}
+
+	// Docker's libnetwork daemon would now move JoinResponse.InterfaceName
+	// into the container's netns itself; CNI has no such daemon, so we do
+	// it ourselves.
+	if err := s.driver.AttachEndpointToNamespace(req.ContainerID, req.Netns, req.IfName); err != nil {
+		s.driver.Leave(&dnetwork.LeaveRequest{NetworkID: req.NetworkName, EndpointID: req.ContainerID})
+		s.driver.DeleteEndpoint(&dnetwork.DeleteEndpointRequest{NetworkID: req.NetworkName, EndpointID: req.ContainerID})
+		return nil, fmt.Errorf("failed to attach endpoint to namespace: %w", err)
+	}
+
+	info, err := s.driver.EndpointInfo(&dnetwork.InfoRequest{NetworkID: req.NetworkName, EndpointID: req.ContainerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read endpoint info: %w", err)
+	}
+
+	return &Response{
+		IPv4Address: info.Value["ipv4_address"],
+		IPv6Address: info.Value["ipv6_address"],
+		MacAddress:  info.Value["mac_address"],
+		Gateway:     joinResp.Gateway,
+		GatewayIPv6: joinResp.GatewayIPv6,
+	}, nil
+}
+
+func (s *Server) del(req *Request) error {
+	if err := s.driver.Leave(&dnetwork.LeaveRequest{NetworkID: req.NetworkName, EndpointID: req.ContainerID}); err != nil {
+		s.logger.WithError(err).Warnf("Failed to leave network for endpoint %s", req.ContainerID)
+	}
+	return s.driver.DeleteEndpoint(&dnetwork.DeleteEndpointRequest{NetworkID: req.NetworkName, EndpointID: req.ContainerID})
+}
+
+func (s *Server) check(req *Request) (*Response, error) {
+	info, err := s.driver.EndpointInfo(&dnetwork.InfoRequest{NetworkID: req.NetworkName, EndpointID: req.ContainerID})
+	if err != nil {
+		return nil, fmt.Errorf("endpoint %s not found: %w", req.ContainerID, err)
+	}
+	return &Response{
+		IPv4Address: info.Value["ipv4_address"],
+		IPv6Address: info.Value["ipv6_address"],
+		MacAddress:  info.Value["mac_address"],
+	}, nil
+}
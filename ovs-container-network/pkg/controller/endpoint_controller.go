@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkv1alpha1 "github.com/ovs-container-lab/ovs-container-network/pkg/apis/network/v1alpha1"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store"
+	"github.com/sirupsen/logrus"
+)
+
+// EndpointReconciler reconciles an Endpoint object into an OVN logical
+// switch port on the Network named by Spec.NetworkRef.
+type EndpointReconciler struct {
+	client.Client
+	OVN    *ovn.Client
+	Store  store.Store
+	Logger *logrus.Logger
+}
+
+// Reconcile implements the controller-runtime Reconciler interface for Endpoint.
+func (r *EndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var epCR networkv1alpha1.Endpoint
+	if err := r.Get(ctx, req.NamespacedName, &epCR); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !epCR.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &epCR)
+	}
+
+	if !controllerutil.ContainsFinalizer(&epCR, networkv1alpha1.EndpointFinalizer) {
+		controllerutil.AddFinalizer(&epCR, networkv1alpha1.EndpointFinalizer)
+		if err := r.Update(ctx, &epCR); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to endpoint %s: %w", epCR.Name, err)
+		}
+	}
+
+	var netCR networkv1alpha1.Network
+	if err := r.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: epCR.Spec.NetworkRef}, &netCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("endpoint %s references network %s: %w", epCR.Name, epCR.Spec.NetworkRef, err)
+	}
+	if netCR.Status.OVNSwitch == "" {
+		// Network hasn't been reconciled yet; retry once it has a switch.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	portName := req.Namespace + "/" + epCR.Name
+	if err := r.OVN.CreateLogicalPort(netCR.Status.OVNSwitch, portName, epCR.Spec.MACAddress, epCR.Spec.IPAddress, nil); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create logical port %s on switch %s: %w", portName, netCR.Status.OVNSwitch, err)
+	}
+	epCR.Status.OVNPort = portName
+
+	// Add the port to the network-wide port group, for parity with the
+	// Docker driver's Join (see reconcileNetworkPolicy/ovn.NetworkPortGroupName) -
+	// so a NetworkPolicy CR's ACLs apply to K8s-originated endpoints too.
+	pgName := ovn.NetworkPortGroupName(string(netCR.UID))
+	if err := r.OVN.CreatePortGroup(pgName); err != nil {
+		r.Logger.WithError(err).Warnf("Failed to ensure network port group %s for endpoint %s", pgName, epCR.Name)
+	} else if err := r.OVN.AddPortToPortGroup(pgName, portName); err != nil {
+		r.Logger.WithError(err).Warnf("Failed to add port %s to network port group %s", portName, pgName)
+	}
+
+	if err := r.Store.SaveEndpoint(&store.EndpointInfo{
+		ID:         portName,
+		NetworkID:  string(netCR.UID),
+		EndpointID: string(epCR.UID),
+		MACAddress: epCR.Spec.MACAddress,
+		IPAddress:  epCR.Spec.IPAddress,
+		OVNPort:    portName,
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to persist endpoint %s: %w", epCR.Name, err)
+	}
+
+	meta.SetStatusCondition(&epCR.Status.Conditions, metav1.Condition{
+		Type:    networkv1alpha1.ConditionReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Reconciled",
+		Message: "logical port created",
+	})
+	if err := r.Status().Update(ctx, &epCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status for endpoint %s: %w", epCR.Name, err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileDelete removes an Endpoint's logical port before clearing its
+// finalizer, mirroring NetworkReconciler.reconcileDelete.
+func (r *EndpointReconciler) reconcileDelete(ctx context.Context, epCR *networkv1alpha1.Endpoint) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(epCR, networkv1alpha1.EndpointFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if epCR.Status.OVNPort != "" {
+		var netCR networkv1alpha1.Network
+		netCRFound := r.Get(ctx, client.ObjectKey{Namespace: epCR.Namespace, Name: epCR.Spec.NetworkRef}, &netCR) == nil
+		if netCRFound {
+			if err := r.OVN.RemovePortFromPortGroup(ovn.NetworkPortGroupName(string(netCR.UID)), epCR.Status.OVNPort); err != nil {
+				r.Logger.WithError(err).Warnf("Failed to remove port %s from network port group for endpoint %s", epCR.Status.OVNPort, epCR.Name)
+			}
+		}
+
+		if err := r.OVN.DeleteLogicalPort(epCR.Status.OVNPort); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete logical port %s for endpoint %s: %w", epCR.Status.OVNPort, epCR.Name, err)
+		}
+
+		if netCRFound {
+			if err := r.Store.DeleteEndpoint(string(netCR.UID), string(epCR.UID)); err != nil {
+				r.Logger.WithError(err).Warnf("Failed to delete persisted state for endpoint %s", epCR.Name)
+			}
+		}
+	}
+
+	controllerutil.RemoveFinalizer(epCR, networkv1alpha1.EndpointFinalizer)
+	if err := r.Update(ctx, epCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from endpoint %s: %w", epCR.Name, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager registers this reconciler with mgr, watching Endpoint CRs.
+func (r *EndpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkv1alpha1.Endpoint{}).
+		Complete(r)
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkv1alpha1 "github.com/ovs-container-lab/ovs-container-network/pkg/apis/network/v1alpha1"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+	"github.com/sirupsen/logrus"
+)
+
+// LogicalRouterReconciler reconciles a LogicalRouter object into a
+// standalone OVN logical router, for topologies where a router isn't owned
+// by any single Network (see LogicalRouterSpec).
+type LogicalRouterReconciler struct {
+	client.Client
+	OVN    *ovn.Client
+	Logger *logrus.Logger
+}
+
+// Reconcile implements the controller-runtime Reconciler interface for
+// LogicalRouter.
+func (r *LogicalRouterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var lrCR networkv1alpha1.LogicalRouter
+	if err := r.Get(ctx, req.NamespacedName, &lrCR); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !lrCR.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &lrCR)
+	}
+
+	if !controllerutil.ContainsFinalizer(&lrCR, networkv1alpha1.LogicalRouterFinalizer) {
+		controllerutil.AddFinalizer(&lrCR, networkv1alpha1.LogicalRouterFinalizer)
+		if err := r.Update(ctx, &lrCR); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to logical router %s: %w", lrCR.Name, err)
+		}
+	}
+
+	routerName := lrCR.Spec.Name
+	if routerName == "" {
+		routerName = lrCR.Name
+	}
+
+	if err := r.OVN.CreateLogicalRouter(routerName, map[string]string{"k8s_logical_router": lrCR.Name}); err != nil {
+		return ctrl.Result{}, r.setCondition(ctx, &lrCR, networkv1alpha1.ConditionOVNRouterCreated, metav1.ConditionFalse, "CreateFailed", err)
+	}
+	lrCR.Status.OVNRouter = routerName
+	if err := r.setCondition(ctx, &lrCR, networkv1alpha1.ConditionOVNRouterCreated, metav1.ConditionTrue, "Created", nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for _, route := range lrCR.Spec.StaticRoutes {
+		if err := r.OVN.AddStaticRoute(routerName, route.Destination, route.NextHop); err != nil {
+			r.Logger.WithError(err).Warnf("Failed to add static route %s via %s on router %s", route.Destination, route.NextHop, routerName)
+		}
+	}
+
+	for _, snat := range lrCR.Spec.SNAT {
+		if err := r.OVN.AddSNAT(routerName, snat.Subnet, snat.ExternalIP); err != nil {
+			r.Logger.WithError(err).Warnf("Failed to add SNAT %s -> %s on router %s", snat.Subnet, snat.ExternalIP, routerName)
+		}
+	}
+
+	if err := r.setCondition(ctx, &lrCR, networkv1alpha1.ConditionReady, metav1.ConditionTrue, "Reconciled", nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// setCondition sets one status condition and pushes the update to the API
+// server, mirroring NetworkReconciler.setCondition.
+func (r *LogicalRouterReconciler) setCondition(ctx context.Context, lrCR *networkv1alpha1.LogicalRouter, condType string, status metav1.ConditionStatus, reason string, cause error) error {
+	message := reason
+	if cause != nil {
+		message = cause.Error()
+	}
+	meta.SetStatusCondition(&lrCR.Status.Conditions, metav1.Condition{
+		Type:    condType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if err := r.Status().Update(ctx, lrCR); err != nil {
+		if apierrors.IsConflict(err) {
+			return err
+		}
+		return fmt.Errorf("failed to update status for logical router %s: %w", lrCR.Name, err)
+	}
+	if cause != nil {
+		return cause
+	}
+	return nil
+}
+
+// reconcileDelete tears down OVN state for a LogicalRouter being deleted,
+// and only then removes the finalizer, mirroring NetworkReconciler.reconcileDelete.
+func (r *LogicalRouterReconciler) reconcileDelete(ctx context.Context, lrCR *networkv1alpha1.LogicalRouter) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(lrCR, networkv1alpha1.LogicalRouterFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if lrCR.Status.OVNRouter != "" {
+		if err := r.OVN.DeleteLogicalRouter(lrCR.Status.OVNRouter); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete logical router %s: %w", lrCR.Status.OVNRouter, err)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(lrCR, networkv1alpha1.LogicalRouterFinalizer)
+	if err := r.Update(ctx, lrCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from logical router %s: %w", lrCR.Name, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager registers this reconciler with mgr, watching
+// LogicalRouter CRs.
+func (r *LogicalRouterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkv1alpha1.LogicalRouter{}).
+		Complete(r)
+}
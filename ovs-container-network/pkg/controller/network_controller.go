@@ -0,0 +1,180 @@
+// Package controller reconciles the Network and Endpoint CRDs
+// (pkg/apis/network/v1alpha1) into OVN logical topology, the same way
+// pkg/driver does for Docker networks/endpoints, so the plugin and a
+// Kubernetes CNI can share one OVN deployment and one store.Store. It
+// follows the Network-CRD-plus-controller pattern ovn4nfv-k8s-plugin uses,
+// built on controller-runtime instead of client-go informers directly.
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkv1alpha1 "github.com/ovs-container-lab/ovs-container-network/pkg/apis/network/v1alpha1"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store"
+	"github.com/sirupsen/logrus"
+)
+
+// NetworkReconciler reconciles a Network object into an OVN logical switch
+// (and, if Spec.Gateway is set, a logical router), persisting the
+// result in Store the same way pkg/driver persists Docker networks.
+type NetworkReconciler struct {
+	client.Client
+	OVN    *ovn.Client
+	Store  store.Store
+	Logger *logrus.Logger
+}
+
+// Reconcile implements the controller-runtime Reconciler interface for Network.
+func (r *NetworkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var netCR networkv1alpha1.Network
+	if err := r.Get(ctx, req.NamespacedName, &netCR); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !netCR.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &netCR)
+	}
+
+	if !controllerutil.ContainsFinalizer(&netCR, networkv1alpha1.NetworkFinalizer) {
+		controllerutil.AddFinalizer(&netCR, networkv1alpha1.NetworkFinalizer)
+		if err := r.Update(ctx, &netCR); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to network %s: %w", netCR.Name, err)
+		}
+	}
+
+	switchName := netCR.Spec.Name
+	if switchName == "" {
+		switchName = netCR.Name
+	}
+
+	externalIDs := map[string]string{"k8s_network": netCR.Name}
+	if netCR.Spec.TenantID != "" {
+		externalIDs["tenant_id"] = netCR.Spec.TenantID
+	}
+	if err := r.OVN.CreateLogicalSwitch(switchName, externalIDs); err != nil {
+		return ctrl.Result{}, r.setCondition(ctx, &netCR, networkv1alpha1.ConditionOVNSwitchCreated, metav1.ConditionFalse, "CreateFailed", err)
+	}
+	netCR.Status.OVNSwitch = switchName
+	if err := r.setCondition(ctx, &netCR, networkv1alpha1.ConditionOVNSwitchCreated, metav1.ConditionTrue, "Created", nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if netCR.Spec.Gateway != "" {
+		routerName := switchName + "-router"
+		if err := r.OVN.CreateLogicalRouter(routerName, externalIDs); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create logical router for network %s: %w", netCR.Name, err)
+		}
+		netCR.Status.OVNRouter = routerName
+
+		for _, route := range netCR.Spec.Routes {
+			if err := r.OVN.AddStaticRoute(routerName, route.Destination, route.NextHop); err != nil {
+				r.Logger.WithError(err).Warnf("Failed to add static route %s via %s on router %s", route.Destination, route.NextHop, routerName)
+			}
+		}
+	}
+
+	if netCR.Spec.Subnet != "" {
+		dhcpOptions := map[string]string{}
+		if netCR.Spec.Gateway != "" {
+			dhcpOptions["router"] = netCR.Spec.Gateway
+		}
+		if len(netCR.Spec.DNSServers) > 0 {
+			dhcpOptions["dns_server"] = netCR.Spec.DNSServers[0]
+		}
+		dhcpUUID, err := r.OVN.CreateDHCPOptions(netCR.Spec.Subnet, "", netCR.Spec.Gateway, dhcpOptions)
+		if err != nil {
+			return ctrl.Result{}, r.setCondition(ctx, &netCR, networkv1alpha1.ConditionDHCPConfigured, metav1.ConditionFalse, "CreateFailed", err)
+		}
+		netCR.Status.DHCPOptionsUUID = dhcpUUID
+		if err := r.setCondition(ctx, &netCR, networkv1alpha1.ConditionDHCPConfigured, metav1.ConditionTrue, "Configured", nil); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.Store.SaveNetwork(&store.NetworkInfo{
+		ID:        string(netCR.UID),
+		Name:      netCR.Name,
+		VLAN:      netCR.Spec.VLAN,
+		TenantID:  netCR.Spec.TenantID,
+		OVNSwitch: netCR.Status.OVNSwitch,
+		OVNRouter: netCR.Status.OVNRouter,
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to persist network %s: %w", netCR.Name, err)
+	}
+
+	if err := r.setCondition(ctx, &netCR, networkv1alpha1.ConditionReady, metav1.ConditionTrue, "Reconciled", nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileDelete tears down OVN state for a Network being deleted, and
+// only then removes the finalizer so the API server can finish the delete.
+func (r *NetworkReconciler) reconcileDelete(ctx context.Context, netCR *networkv1alpha1.Network) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(netCR, networkv1alpha1.NetworkFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if netCR.Status.OVNRouter != "" {
+		if err := r.OVN.DeleteLogicalRouter(netCR.Status.OVNRouter); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete logical router %s for network %s: %w", netCR.Status.OVNRouter, netCR.Name, err)
+		}
+	}
+	if netCR.Status.OVNSwitch != "" {
+		if err := r.OVN.DeleteLogicalSwitch(netCR.Status.OVNSwitch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete logical switch %s for network %s: %w", netCR.Status.OVNSwitch, netCR.Name, err)
+		}
+	}
+	if err := r.Store.DeleteNetwork(string(netCR.UID)); err != nil {
+		r.Logger.WithError(err).Warnf("Failed to delete persisted state for network %s", netCR.Name)
+	}
+
+	controllerutil.RemoveFinalizer(netCR, networkv1alpha1.NetworkFinalizer)
+	if err := r.Update(ctx, netCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from network %s: %w", netCR.Name, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// setCondition sets one status condition and pushes the update to the API
+// server; apierrors.IsConflict is treated as retryable by returning the
+// error so the caller's Reconcile requeues.
+func (r *NetworkReconciler) setCondition(ctx context.Context, netCR *networkv1alpha1.Network, condType string, status metav1.ConditionStatus, reason string, cause error) error {
+	message := reason
+	if cause != nil {
+		message = cause.Error()
+	}
+	meta.SetStatusCondition(&netCR.Status.Conditions, metav1.Condition{
+		Type:    condType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if err := r.Status().Update(ctx, netCR); err != nil {
+		if apierrors.IsConflict(err) {
+			return err
+		}
+		return fmt.Errorf("failed to update status for network %s: %w", netCR.Name, err)
+	}
+	if cause != nil {
+		return cause
+	}
+	return nil
+}
+
+// SetupWithManager registers this reconciler with mgr, watching Network CRs.
+func (r *NetworkReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkv1alpha1.Network{}).
+		Complete(r)
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkv1alpha1 "github.com/ovs-container-lab/ovs-container-network/pkg/apis/network/v1alpha1"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/driver/policy"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+	"github.com/sirupsen/logrus"
+)
+
+// NetworkPolicyReconciler reconciles a NetworkPolicy object onto its
+// Spec.NetworkRef's network-wide Port_Group, compiling Spec.Rules into ACLs
+// via pkg/driver/policy - reused directly here rather than through
+// pkg/driver.PolicyManager, since policy.SetACL already operates on
+// *ovn.Client alone and has nothing Docker-specific to adapt away.
+type NetworkPolicyReconciler struct {
+	client.Client
+	OVN    *ovn.Client
+	Logger *logrus.Logger
+}
+
+// Reconcile implements the controller-runtime Reconciler interface for
+// NetworkPolicy.
+func (r *NetworkPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var npCR networkv1alpha1.NetworkPolicy
+	if err := r.Get(ctx, req.NamespacedName, &npCR); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !npCR.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &npCR)
+	}
+
+	if !controllerutil.ContainsFinalizer(&npCR, networkv1alpha1.NetworkPolicyFinalizer) {
+		controllerutil.AddFinalizer(&npCR, networkv1alpha1.NetworkPolicyFinalizer)
+		if err := r.Update(ctx, &npCR); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to network policy %s: %w", npCR.Name, err)
+		}
+	}
+
+	var netCR networkv1alpha1.Network
+	if err := r.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: npCR.Spec.NetworkRef}, &netCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("network policy %s references network %s: %w", npCR.Name, npCR.Spec.NetworkRef, err)
+	}
+
+	networkID := string(netCR.UID)
+	rules := toDriverPolicyRules(npCR.Spec.Rules)
+	hash := policy.Hash(rules)
+	if npCR.Status.AppliedHash == hash {
+		r.Logger.Debugf("Network policy %s unchanged, skipping reprogramming", npCR.Name)
+		return ctrl.Result{}, nil
+	}
+
+	pgName := ovn.NetworkPortGroupName(networkID)
+	if err := r.OVN.CreatePortGroup(pgName); err != nil {
+		return ctrl.Result{}, r.setCondition(ctx, &npCR, networkv1alpha1.ConditionPolicyApplied, metav1.ConditionFalse, "ApplyFailed", err)
+	}
+
+	for _, rule := range rules {
+		if rule.Selector != "" {
+			if err := r.OVN.CreateAddressSet(ovn.AddressSetName(networkID, rule.Selector)); err != nil {
+				return ctrl.Result{}, r.setCondition(ctx, &npCR, networkv1alpha1.ConditionPolicyApplied, metav1.ConditionFalse, "ApplyFailed", err)
+			}
+		}
+	}
+
+	if err := policy.SetACL(r.OVN, networkID, rules, ""); err != nil {
+		return ctrl.Result{}, r.setCondition(ctx, &npCR, networkv1alpha1.ConditionPolicyApplied, metav1.ConditionFalse, "ApplyFailed", err)
+	}
+	npCR.Status.AppliedHash = hash
+	if err := r.setCondition(ctx, &npCR, networkv1alpha1.ConditionPolicyApplied, metav1.ConditionTrue, "Applied", nil); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.setCondition(ctx, &npCR, networkv1alpha1.ConditionReady, metav1.ConditionTrue, "Reconciled", nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.Logger.Infof("Applied network policy %s to network %s", npCR.Name, npCR.Spec.NetworkRef)
+	return ctrl.Result{}, nil
+}
+
+// reconcileDelete clears a NetworkPolicy's ACLs before removing its
+// finalizer, mirroring NetworkReconciler.reconcileDelete.
+func (r *NetworkPolicyReconciler) reconcileDelete(ctx context.Context, npCR *networkv1alpha1.NetworkPolicy) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(npCR, networkv1alpha1.NetworkPolicyFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	var netCR networkv1alpha1.Network
+	if err := r.Get(ctx, client.ObjectKey{Namespace: npCR.Namespace, Name: npCR.Spec.NetworkRef}, &netCR); err == nil {
+		if err := policy.DeleteACL(r.OVN, string(netCR.UID)); err != nil {
+			r.Logger.WithError(err).Warnf("Failed to clear ACLs for network policy %s", npCR.Name)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(npCR, networkv1alpha1.NetworkPolicyFinalizer)
+	if err := r.Update(ctx, npCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from network policy %s: %w", npCR.Name, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// toDriverPolicyRules converts the CRD's PolicyRule list into
+// pkg/driver/policy.Rule, field-for-field.
+func toDriverPolicyRules(rules []networkv1alpha1.PolicyRule) []policy.Rule {
+	out := make([]policy.Rule, len(rules))
+	for i, rule := range rules {
+		out[i] = policy.Rule{
+			Direction: rule.Direction,
+			Selector:  rule.Selector,
+			CIDR:      rule.CIDR,
+			Protocol:  rule.Protocol,
+			PortMin:   rule.PortMin,
+			PortMax:   rule.PortMax,
+			CtState:   rule.CtState,
+			Action:    rule.Action,
+			Priority:  rule.Priority,
+			Log:       rule.Log,
+		}
+	}
+	return out
+}
+
+// setCondition sets one status condition and pushes the update to the API
+// server, mirroring NetworkReconciler.setCondition.
+func (r *NetworkPolicyReconciler) setCondition(ctx context.Context, npCR *networkv1alpha1.NetworkPolicy, condType string, status metav1.ConditionStatus, reason string, cause error) error {
+	message := reason
+	if cause != nil {
+		message = cause.Error()
+	}
+	meta.SetStatusCondition(&npCR.Status.Conditions, metav1.Condition{
+		Type:    condType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if err := r.Status().Update(ctx, npCR); err != nil {
+		if apierrors.IsConflict(err) {
+			return err
+		}
+		return fmt.Errorf("failed to update status for network policy %s: %w", npCR.Name, err)
+	}
+	if cause != nil {
+		return cause
+	}
+	return nil
+}
+
+// SetupWithManager registers this reconciler with mgr, watching
+// NetworkPolicy CRs.
+func (r *NetworkPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkv1alpha1.NetworkPolicy{}).
+		Complete(r)
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkv1alpha1 "github.com/ovs-container-lab/ovs-container-network/pkg/apis/network/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// PodNetworkAnnotation names the Network a Pod wants an Endpoint on. Pods
+// without it are ignored by PodReconciler.
+const PodNetworkAnnotation = "network.ovs-container-lab.io/network"
+
+// PodReconciler watches Pods carrying PodNetworkAnnotation and maintains one
+// Endpoint CR per such Pod, so EndpointReconciler provisions its OVN
+// logical port the same way it does for an Endpoint created directly. It
+// doesn't touch OVN itself - the Endpoint CR is the only thing it manages,
+// with an owner reference doing Pod-deletion cleanup instead of a finalizer
+// or a reconcileDelete path.
+type PodReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Logger *logrus.Logger
+}
+
+// Reconcile implements the controller-runtime Reconciler interface for Pod.
+func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pod corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	networkName, ok := pod.Annotations[PodNetworkAnnotation]
+	if !ok || networkName == "" || !pod.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if pod.Status.PodIP == "" {
+		// Not yet assigned an IP by the kubelet/CRI; retry once it has one.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	var netCR networkv1alpha1.Network
+	if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: networkName}, &netCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("pod %s references network %s: %w", pod.Name, networkName, err)
+	}
+
+	prefixLen := 32
+	if _, ipNet, err := net.ParseCIDR(netCR.Spec.Subnet); err == nil {
+		prefixLen, _ = ipNet.Mask.Size()
+	}
+
+	epCR := &networkv1alpha1.Endpoint{
+		ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, epCR, func() error {
+		epCR.Spec.NetworkRef = networkName
+		epCR.Spec.IPAddress = fmt.Sprintf("%s/%d", pod.Status.PodIP, prefixLen)
+		return controllerutil.SetControllerReference(&pod, epCR, r.Scheme)
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile endpoint for pod %s: %w", pod.Name, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		r.Logger.Infof("%s endpoint %s/%s for pod %s on network %s", result, pod.Namespace, pod.Name, pod.Name, networkName)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager registers this reconciler with mgr, watching Pods.
+func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Scheme = mgr.GetScheme()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}).
+		Owns(&networkv1alpha1.Endpoint{}).
+		Complete(r)
+}
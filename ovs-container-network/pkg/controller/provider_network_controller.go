@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/ovs-container-lab/ovs-container-network/pkg/apis/network/v1alpha1"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovs"
+	"github.com/sirupsen/logrus"
+)
+
+// ProviderNetworkReconciler reconciles a ProviderNetwork object by checking
+// this node's OVS bridge mappings against Spec, not by creating anything:
+// the mapping is chassis-level host configuration owned by whatever
+// provisioned this node, not an OVN Northbound object this plugin manages.
+type ProviderNetworkReconciler struct {
+	client.Client
+	OVS    *ovs.Client
+	Logger *logrus.Logger
+}
+
+// Reconcile implements the controller-runtime Reconciler interface for
+// ProviderNetwork. There is no finalizer here, since there is nothing for
+// reconcileDelete to tear down.
+func (r *ProviderNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pnCR networkv1alpha1.ProviderNetwork
+	if err := r.Get(ctx, req.NamespacedName, &pnCR); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	mappings, err := r.OVS.BridgeMappings()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to read bridge mappings for provider network %s: %w", pnCR.Name, err)
+	}
+
+	present := hasBridgeMapping(mappings, pnCR.Spec.PhysicalNetwork, pnCR.Spec.Bridge)
+
+	status := metav1.ConditionFalse
+	reason := "MappingMissing"
+	message := fmt.Sprintf("ovn-bridge-mappings %q does not map %s to %s", mappings, pnCR.Spec.PhysicalNetwork, pnCR.Spec.Bridge)
+	if present {
+		status = metav1.ConditionTrue
+		reason = "MappingPresent"
+		message = fmt.Sprintf("%s is mapped to %s", pnCR.Spec.PhysicalNetwork, pnCR.Spec.Bridge)
+	}
+
+	meta.SetStatusCondition(&pnCR.Status.Conditions, metav1.Condition{
+		Type:    networkv1alpha1.ConditionBridgeMappingPresent,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if err := r.Status().Update(ctx, &pnCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status for provider network %s: %w", pnCR.Name, err)
+	}
+	if !present {
+		r.Logger.Warnf("Provider network %s: %s", pnCR.Name, message)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// hasBridgeMapping reports whether mappings - ovn-bridge-mappings' own
+// comma-separated "physnet:bridge,..." format - contains physnet mapped to
+// bridge.
+func hasBridgeMapping(mappings, physnet, bridge string) bool {
+	for _, entry := range strings.Split(mappings, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 2)
+		if len(parts) == 2 && parts[0] == physnet && parts[1] == bridge {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager registers this reconciler with mgr, watching
+// ProviderNetwork CRs.
+func (r *ProviderNetworkReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkv1alpha1.ProviderNetwork{}).
+		Complete(r)
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	networkv1alpha1 "github.com/ovs-container-lab/ovs-container-network/pkg/apis/network/v1alpha1"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+	"github.com/sirupsen/logrus"
+)
+
+// VLANAttachmentReconciler reconciles a VLANAttachment object into a
+// type=localnet logical switch port on Spec.NetworkRef's switch, pinned to
+// Spec.ProviderNetworkRef's physical network and tagged with Spec.VLAN - the
+// same localnet port mechanism pkg/driver's setupU2OInterconnection uses for
+// its own provider-network uplink.
+type VLANAttachmentReconciler struct {
+	client.Client
+	OVN    *ovn.Client
+	Logger *logrus.Logger
+}
+
+// Reconcile implements the controller-runtime Reconciler interface for
+// VLANAttachment.
+func (r *VLANAttachmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var vaCR networkv1alpha1.VLANAttachment
+	if err := r.Get(ctx, req.NamespacedName, &vaCR); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !vaCR.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &vaCR)
+	}
+
+	if !controllerutil.ContainsFinalizer(&vaCR, networkv1alpha1.VLANAttachmentFinalizer) {
+		controllerutil.AddFinalizer(&vaCR, networkv1alpha1.VLANAttachmentFinalizer)
+		if err := r.Update(ctx, &vaCR); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to VLAN attachment %s: %w", vaCR.Name, err)
+		}
+	}
+
+	var netCR networkv1alpha1.Network
+	if err := r.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: vaCR.Spec.NetworkRef}, &netCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("VLAN attachment %s references network %s: %w", vaCR.Name, vaCR.Spec.NetworkRef, err)
+	}
+	if netCR.Status.OVNSwitch == "" {
+		// Network hasn't been reconciled yet; retry once it has a switch.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	var pnCR networkv1alpha1.ProviderNetwork
+	if err := r.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: vaCR.Spec.ProviderNetworkRef}, &pnCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("VLAN attachment %s references provider network %s: %w", vaCR.Name, vaCR.Spec.ProviderNetworkRef, err)
+	}
+
+	portName := fmt.Sprintf("vlan-%s", req.Namespace+"-"+vaCR.Name)
+	options := map[string]string{
+		"type":         "localnet",
+		"network_name": pnCR.Spec.PhysicalNetwork,
+	}
+	if vaCR.Spec.VLAN != 0 {
+		options["tag"] = fmt.Sprintf("%d", vaCR.Spec.VLAN)
+	}
+	if err := r.OVN.CreateLogicalPort(netCR.Status.OVNSwitch, portName, "", "", options); err != nil {
+		return ctrl.Result{}, r.setCondition(ctx, &vaCR, networkv1alpha1.ConditionLocalnetPortCreated, metav1.ConditionFalse, "CreateFailed", err)
+	}
+	vaCR.Status.OVNPort = portName
+	if err := r.setCondition(ctx, &vaCR, networkv1alpha1.ConditionLocalnetPortCreated, metav1.ConditionTrue, "Created", nil); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.setCondition(ctx, &vaCR, networkv1alpha1.ConditionReady, metav1.ConditionTrue, "Reconciled", nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileDelete removes a VLANAttachment's localnet port before clearing
+// its finalizer, mirroring EndpointReconciler.reconcileDelete.
+func (r *VLANAttachmentReconciler) reconcileDelete(ctx context.Context, vaCR *networkv1alpha1.VLANAttachment) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(vaCR, networkv1alpha1.VLANAttachmentFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if vaCR.Status.OVNPort != "" {
+		if err := r.OVN.DeleteLogicalPort(vaCR.Status.OVNPort); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete localnet port %s for VLAN attachment %s: %w", vaCR.Status.OVNPort, vaCR.Name, err)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(vaCR, networkv1alpha1.VLANAttachmentFinalizer)
+	if err := r.Update(ctx, vaCR); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from VLAN attachment %s: %w", vaCR.Name, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// setCondition sets one status condition and pushes the update to the API
+// server, mirroring NetworkReconciler.setCondition.
+func (r *VLANAttachmentReconciler) setCondition(ctx context.Context, vaCR *networkv1alpha1.VLANAttachment, condType string, status metav1.ConditionStatus, reason string, cause error) error {
+	message := reason
+	if cause != nil {
+		message = cause.Error()
+	}
+	meta.SetStatusCondition(&vaCR.Status.Conditions, metav1.Condition{
+		Type:    condType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if err := r.Status().Update(ctx, vaCR); err != nil {
+		if apierrors.IsConflict(err) {
+			return err
+		}
+		return fmt.Errorf("failed to update status for VLAN attachment %s: %w", vaCR.Name, err)
+	}
+	if cause != nil {
+		return cause
+	}
+	return nil
+}
+
+// SetupWithManager registers this reconciler with mgr, watching
+// VLANAttachment CRs.
+func (r *VLANAttachmentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkv1alpha1.VLANAttachment{}).
+		Complete(r)
+}
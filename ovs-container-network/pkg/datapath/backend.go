@@ -0,0 +1,54 @@
+// Package datapath abstracts the OS-specific primitives the driver needs to
+// wire a container into a bridge (link-pair creation, namespace moves, bridge
+// existence checks) behind a single Backend interface, so the rest of the
+// plugin doesn't need to know whether it's running on Linux, FreeBSD, or
+// Solaris.
+package datapath
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Backend performs the OS-level networking operations needed to attach a
+// container to a bridge. Implementations live in per-GOOS files
+// (datapath_linux.go, datapath_freebsd.go, datapath_solaris.go) selected at
+// compile time by Go build constraints.
+type Backend interface {
+	// EnsureBridge makes sure a bridge-like construct named `bridge` exists
+	// using whatever mechanism the platform offers (Linux and FreeBSD create
+	// an OVS bridge via ovs-vsctl; Solaris uses an etherstub).
+	EnsureBridge(bridge string) error
+
+	// CreateLinkPair creates a pair of connected virtual interfaces: `host`
+	// stays in the host namespace, `peer` is attached to the bridge/switch.
+	CreateLinkPair(host, peer string) error
+
+	// DeleteLinkPair removes a link pair previously created with
+	// CreateLinkPair. Deleting either end removes both.
+	DeleteLinkPair(host, peer string) error
+
+	// MoveToNamespace moves the host-side interface `name` into the network
+	// namespace identified by `nsPath` (e.g. a container's /proc/<pid>/ns/net
+	// path or equivalent).
+	MoveToNamespace(name, nsPath string) error
+
+	// LinkMAC returns the hardware address assigned to interface `name`.
+	LinkMAC(name string) (string, error)
+
+	// Name identifies the backend for logging, e.g. "linux", "freebsd".
+	Name() string
+}
+
+// newPlatformBackend is implemented once per GOOS in datapath_<os>.go.
+var newPlatformBackend func(logger *logrus.Logger) (Backend, error)
+
+// NewBackend selects and constructs the Backend for the current GOOS.
+func NewBackend(logger *logrus.Logger) (Backend, error) {
+	if newPlatformBackend == nil {
+		return nil, fmt.Errorf("datapath: no backend registered for GOOS=%s", runtime.GOOS)
+	}
+	return newPlatformBackend(logger)
+}
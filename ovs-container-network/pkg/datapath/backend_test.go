@@ -0,0 +1,15 @@
+package datapath
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBackendSelectsCurrentPlatform(t *testing.T) {
+	backend, err := NewBackend(logrus.New())
+	require.NoError(t, err)
+	assert.NotEmpty(t, backend.Name())
+}
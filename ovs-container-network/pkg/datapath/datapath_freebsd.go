@@ -0,0 +1,117 @@
+//go:build freebsd
+
+package datapath
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+func init() {
+	newPlatformBackend = newFreeBSDBackend
+}
+
+// freebsdBackend implements Backend using OVS bridges and ifconfig(8)
+// epair(4) pairs. OVS itself runs on FreeBSD too (the kernel module and
+// userspace tools are ported), so bridge membership is still handed off to
+// ovs-vsctl; only link-pair creation needs a FreeBSD-specific mechanism
+// since Linux veth has no FreeBSD equivalent.
+type freebsdBackend struct {
+	logger *logrus.Logger
+}
+
+func newFreeBSDBackend(logger *logrus.Logger) (Backend, error) {
+	return &freebsdBackend{logger: logger}, nil
+}
+
+func (b *freebsdBackend) Name() string { return "freebsd" }
+
+func (b *freebsdBackend) EnsureBridge(bridge string) error {
+	cmd := exec.Command("ovs-vsctl", "br-exists", bridge)
+	if err := cmd.Run(); err == nil {
+		return nil
+	}
+	cmd = exec.Command("ovs-vsctl", "add-br", bridge)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create bridge %s: %w (output: %s)", bridge, err, string(output))
+	}
+	return nil
+}
+
+// CreateLinkPair creates an epair(4) pair. ifconfig epair create returns the
+// "a" side name (e.g. epair0a); the "b" side is the same base with a "b"
+// suffix. We then rename both ends to match the requested names.
+func (b *freebsdBackend) CreateLinkPair(host, peer string) error {
+	cmd := exec.Command("ifconfig", "epair", "create")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to create epair: %w (output: %s)", err, string(output))
+	}
+
+	aSide := strings.TrimSpace(string(output))
+	bSide := strings.TrimSuffix(aSide, "a") + "b"
+
+	if err := renameInterface(aSide, host); err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", aSide, host, err)
+	}
+	if err := renameInterface(bSide, peer); err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", bSide, peer, err)
+	}
+
+	for _, name := range []string{host, peer} {
+		if output, err := exec.Command("ifconfig", name, "up").CombinedOutput(); err != nil {
+			b.logger.Warnf("Failed to bring up %s: %v (output: %s)", name, err, string(output))
+		}
+	}
+
+	b.logger.Infof("Created epair %s <-> %s", host, peer)
+	return nil
+}
+
+func renameInterface(oldName, newName string) error {
+	output, err := exec.Command("ifconfig", oldName, "name", newName).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w (output: %s)", err, string(output))
+	}
+	return nil
+}
+
+func (b *freebsdBackend) DeleteLinkPair(host, peer string) error {
+	// Destroying either side of an epair destroys both.
+	if output, err := exec.Command("ifconfig", host, "destroy").CombinedOutput(); err == nil {
+		return nil
+	} else {
+		b.logger.Debugf("ifconfig destroy %s failed, trying peer: %v (output: %s)", host, err, string(output))
+	}
+	if output, err := exec.Command("ifconfig", peer, "destroy").CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to destroy epair %s/%s: %w (output: %s)", host, peer, err, string(output))
+	}
+	return nil
+}
+
+func (b *freebsdBackend) MoveToNamespace(name, nsPath string) error {
+	// FreeBSD uses vnet jails rather than Linux network namespaces; nsPath
+	// is expected to be a jail name here.
+	output, err := exec.Command("ifconfig", name, "vnet", nsPath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to move %s into vnet jail %s: %w (output: %s)", name, nsPath, err, string(output))
+	}
+	return nil
+}
+
+func (b *freebsdBackend) LinkMAC(name string) (string, error) {
+	output, err := exec.Command("ifconfig", name).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to query %s: %w (output: %s)", name, err, string(output))
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "ether ") {
+			return strings.TrimPrefix(line, "ether "), nil
+		}
+	}
+	return "", fmt.Errorf("no ether line found for %s", name)
+}
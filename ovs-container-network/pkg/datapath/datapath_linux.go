@@ -0,0 +1,107 @@
+//go:build linux
+
+package datapath
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+func init() {
+	newPlatformBackend = newLinuxBackend
+}
+
+// linuxBackend implements Backend using netlink veth pairs and ovs-vsctl,
+// the mechanism the plugin has always used on Linux.
+type linuxBackend struct {
+	logger *logrus.Logger
+}
+
+func newLinuxBackend(logger *logrus.Logger) (Backend, error) {
+	return &linuxBackend{logger: logger}, nil
+}
+
+func (b *linuxBackend) Name() string { return "linux" }
+
+// EnsureBridge creates the OVS bridge with ovs-vsctl add-br unless
+// ovs-vsctl br-exists already reports it.
+func (b *linuxBackend) EnsureBridge(bridge string) error {
+	cmd := exec.Command("ovs-vsctl", "br-exists", bridge)
+	if err := cmd.Run(); err == nil {
+		return nil
+	}
+	cmd = exec.Command("ovs-vsctl", "add-br", bridge)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create bridge %s: %w (output: %s)", bridge, err, string(output))
+	}
+	return nil
+}
+
+func (b *linuxBackend) CreateLinkPair(host, peer string) error {
+	if _, err := netlink.LinkByName(host); err == nil {
+		b.logger.Warnf("Link %s already exists, deleting it", host)
+		if link, err := netlink.LinkByName(host); err == nil {
+			netlink.LinkDel(link)
+		}
+	}
+
+	veth := &netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: host},
+		PeerName:  peer,
+	}
+	if err := netlink.LinkAdd(veth); err != nil {
+		return fmt.Errorf("failed to create veth pair %s <-> %s: %w", host, peer, err)
+	}
+
+	for _, name := range []string{host, peer} {
+		if link, err := netlink.LinkByName(name); err == nil {
+			if err := netlink.LinkSetUp(link); err != nil {
+				b.logger.Warnf("Failed to bring up %s: %v", name, err)
+			}
+		}
+	}
+
+	b.logger.Infof("Created veth pair %s <-> %s", host, peer)
+	return nil
+}
+
+func (b *linuxBackend) DeleteLinkPair(host, peer string) error {
+	if link, err := netlink.LinkByName(host); err == nil {
+		return netlink.LinkDel(link)
+	}
+	if link, err := netlink.LinkByName(peer); err == nil {
+		return netlink.LinkDel(link)
+	}
+	return nil
+}
+
+func (b *linuxBackend) MoveToNamespace(name, nsPath string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to find link %s: %w", name, err)
+	}
+
+	nsFile, err := os.Open(nsPath)
+	if err != nil {
+		return fmt.Errorf("failed to open namespace %s: %w", nsPath, err)
+	}
+	defer nsFile.Close()
+
+	if err := netlink.LinkSetNsFd(link, int(nsFile.Fd())); err != nil {
+		return fmt.Errorf("failed to move %s to namespace %s: %w", name, nsPath, err)
+	}
+
+	return nil
+}
+
+func (b *linuxBackend) LinkMAC(name string) (string, error) {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to find link %s: %w", name, err)
+	}
+	return link.Attrs().HardwareAddr.String(), nil
+}
@@ -0,0 +1,84 @@
+//go:build solaris
+
+package datapath
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+func init() {
+	newPlatformBackend = newSolarisBackend
+}
+
+// solarisBackend implements Backend on top of Crossbow (dladm(1M)): an
+// etherstub stands in for the OVS bridge, and VNICs stand in for veth pairs.
+// There is no OVS port on Solaris, so this backend owns the full bridge
+// lifecycle itself rather than delegating to ovs-vsctl.
+type solarisBackend struct {
+	logger *logrus.Logger
+}
+
+func newSolarisBackend(logger *logrus.Logger) (Backend, error) {
+	return &solarisBackend{logger: logger}, nil
+}
+
+func (b *solarisBackend) Name() string { return "solaris" }
+
+func (b *solarisBackend) EnsureBridge(bridge string) error {
+	cmd := exec.Command("dladm", "show-etherstub", bridge)
+	if err := cmd.Run(); err == nil {
+		b.logger.Debugf("Etherstub %s already exists", bridge)
+		return nil
+	}
+
+	output, err := exec.Command("dladm", "create-etherstub", bridge).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to create etherstub %s: %w (output: %s)", bridge, err, string(output))
+	}
+
+	b.logger.Infof("Created etherstub %s", bridge)
+	return nil
+}
+
+// CreateLinkPair creates a single VNIC named `host` over the existing link
+// `peer` (typically the etherstub). Crossbow VNICs don't come in connected
+// pairs the way veth/epair do, so no interface named `peer` is created;
+// `host` is left for the caller (typically moved into a zone).
+func (b *solarisBackend) CreateLinkPair(host, peer string) error {
+	output, err := exec.Command("dladm", "create-vnic", "-l", peer, host).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to create vnic %s over %s: %w (output: %s)", host, peer, err, string(output))
+	}
+
+	b.logger.Infof("Created VNIC %s over link %s", host, peer)
+	return nil
+}
+
+func (b *solarisBackend) DeleteLinkPair(host, peer string) error {
+	output, err := exec.Command("dladm", "delete-vnic", host).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to delete vnic %s: %w (output: %s)", host, err, string(output))
+	}
+	return nil
+}
+
+func (b *solarisBackend) MoveToNamespace(name, nsPath string) error {
+	// nsPath is expected to be a zone name on Solaris.
+	output, err := exec.Command("dladm", "set-linkprop", "-p", "zone="+nsPath, name).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to assign %s to zone %s: %w (output: %s)", name, nsPath, err, string(output))
+	}
+	return nil
+}
+
+func (b *solarisBackend) LinkMAC(name string) (string, error) {
+	output, err := exec.Command("dladm", "show-vnic", "-p", "-o", "macaddress", name).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to query vnic %s: %w (output: %s)", name, err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
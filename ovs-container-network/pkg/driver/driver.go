@@ -5,9 +5,10 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"net"
 	"os"
-	"os/exec"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -20,12 +21,18 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	dnetwork "github.com/docker/go-plugins-helpers/network"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/driver/policy"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/eip"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ipam"
 	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+	ovnchassis "github.com/ovs-container-lab/ovs-container-network/pkg/ovn/chassis"
 	"github.com/ovs-container-lab/ovs-container-network/pkg/ovs"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/portmap"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/snat"
 	"github.com/ovs-container-lab/ovs-container-network/pkg/store"
 	"github.com/ovs-container-lab/ovs-container-network/pkg/types"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/vlan"
 	"github.com/sirupsen/logrus"
-	"github.com/vishvananda/netlink"
 )
 
 // Driver implements the Docker network driver interface
@@ -33,24 +40,34 @@ type Driver struct {
 	sync.RWMutex
 	networks  map[string]*types.Network
 	endpoints map[string]*types.Endpoint
+	sandboxes map[string]*types.Endpoint // Active endpoints keyed by sandbox key, rebuilt on restart by recoverState
 	ovs       *ovs.Client
 	ovn       *ovn.Client // Optional OVN client
-	store     *store.Store
+	store     store.Store
+	snat      *snat.Manager          // Manages SNAT-on-host allocations and iptables rules
+	portmap   *portmap.Manager       // Manages published-port host allocations and OVN load balancer VIPs
+	eip       *eip.Manager           // Manages elastic/floating IP allocations
+	ipam      *ipam.TransitAllocator // Manages VPC router port allocations on the shared transit network
 	logger    *logrus.Logger
 }
 
-// New creates a new OVS network driver
-func New() (*Driver, error) {
+// New creates a new OVS network driver, using storeURL to select and
+// configure its persistent store backend (e.g. "bolt:///data/store.db",
+// "file:///data", "etcd://host:2379", "consul://host:8500"). An empty
+// storeURL falls back to PLUGIN_DATA_DIR as a "file://" path (for
+// compatibility with pre-bolt deployments that already have JSON state on
+// disk there) and then to the bolt backend's own default path.
+func New(storeURL string) (*Driver, error) {
 	logger := logrus.New()
 	logger.SetLevel(logrus.GetLevel())
 
-	// Initialize persistent store
-	dataDir := os.Getenv("PLUGIN_DATA_DIR")
-	if dataDir == "" {
-		dataDir = "/data"
+	if storeURL == "" {
+		if dataDir := os.Getenv("PLUGIN_DATA_DIR"); dataDir != "" {
+			storeURL = "file://" + dataDir
+		}
 	}
 
-	pluginStore, err := store.NewStore(dataDir)
+	pluginStore, err := store.New(storeURL, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize store: %w", err)
 	}
@@ -68,8 +85,13 @@ func New() (*Driver, error) {
 	driver := &Driver{
 		networks:  make(map[string]*types.Network),
 		endpoints: make(map[string]*types.Endpoint),
+		sandboxes: make(map[string]*types.Endpoint),
 		ovs:       ovsClient,
 		store:     pluginStore,
+		snat:      snat.NewManager(logger),
+		portmap:   portmap.NewManager(logger),
+		eip:       eip.NewManager(logger),
+		ipam:      ipam.NewTransitAllocator(logger),
 		logger:    logger,
 	}
 
@@ -97,11 +119,15 @@ func (d *Driver) recoverState() error {
 
 		// Reconstruct network object
 		network := &types.Network{
-			ID:       netInfo.ID,
-			Bridge:   netInfo.Bridge,
-			VLAN:     fmt.Sprintf("%d", netInfo.VLAN),
-			TenantID: netInfo.TenantID,
-			Options:  netInfo.Options,
+			ID:               netInfo.ID,
+			Bridge:           netInfo.Bridge,
+			VLAN:             fmt.Sprintf("%d", netInfo.VLAN),
+			TenantID:         netInfo.TenantID,
+			EnableSNATOnHost: netInfo.EnableSNATOnHost,
+			InfraVNetCIDR:    netInfo.InfraVNetCIDR,
+			Mode:             netInfo.Mode,
+			Parent:           netInfo.Parent,
+			Options:          netInfo.Options,
 		}
 
 		// Verify OVS bridge still exists (just check if we can list it)
@@ -132,32 +158,312 @@ func (d *Driver) recoverState() error {
 		d.networks[netInfo.ID] = network
 	}
 
-	// Load endpoints from store
-	// Note: We don't recreate veth pairs here as Docker will call CreateEndpoint again
-	// for any active containers when they restart
+	// Load endpoints from store, rebuilding ActiveSandboxes and reconciling
+	// each endpoint's persisted veth/port against what's actually still
+	// there (live-restore), rather than waiting for Docker to call
+	// CreateEndpoint/Join again.
 	endpoints := d.store.ListEndpoints()
+	report := store.RecoveryReport{}
+
 	for _, epInfo := range endpoints {
 		d.logger.Infof("Recovering endpoint %s on network %s", epInfo.EndpointID, epInfo.NetworkID)
 
 		endpoint := &types.Endpoint{
 			ID:          epInfo.EndpointID,
 			NetworkID:   epInfo.NetworkID,
+			Network:     d.networks[epInfo.NetworkID],
 			VethName:    epInfo.VethName,
+			PortName:    epInfo.PortName,
+			SandboxKey:  epInfo.SandboxKey,
 			MacAddress:  epInfo.MACAddress,
 			IPv4Address: epInfo.IPAddress,
+			IPv6Address: epInfo.IPv6Address,
+			SNATAddress: epInfo.SNATAddress,
+			EIPID:       epInfo.EIPID,
+		}
+		for _, pb := range epInfo.PortBindings {
+			endpoint.PortBindings = append(endpoint.PortBindings, types.PortBinding{
+				Proto:         pb.Proto,
+				ContainerPort: pb.ContainerPort,
+				HostIP:        pb.HostIP,
+				HostPort:      pb.HostPort,
+				HostPortEnd:   pb.HostPortEnd,
+				LBUUID:        pb.LBUUID,
+			})
+			d.portmap.Reserve(epInfo.NetworkID, epInfo.EndpointID, pb.Proto, pb.HostIP, pb.HostPort, pb.HostPortEnd, pb.ContainerPort)
+		}
+		for _, sec := range epInfo.SecondaryInterfaces {
+			endpoint.SecondaryInterfaces = append(endpoint.SecondaryInterfaces, types.SecondaryInterface{
+				IfName:      sec.IfName,
+				NetworkID:   sec.NetworkID,
+				MacAddress:  sec.MacAddress,
+				IPv4Address: sec.IPv4Address,
+				VethName:    sec.VethName,
+				PortName:    sec.PortName,
+				OVNPort:     sec.OVNPort,
+			})
+		}
+
+		// Store in memory map, keyed the same way CreateEndpoint/Join/Leave look it up
+		d.endpoints[epInfo.EndpointID] = endpoint
+
+		sandboxKey := epInfo.SandboxKey
+		if sandboxKey == "" {
+			sandboxKey = epInfo.EndpointID
+		}
+		d.sandboxes[sandboxKey] = endpoint
+
+		// Re-register the SNAT allocation and repair the host iptables rule if
+		// it didn't survive the restart (e.g. the host rebooted, not just the plugin).
+		if epInfo.SNATAddress != "" {
+			netInfo, err := d.store.GetNetwork(epInfo.NetworkID)
+			if err == nil {
+				d.snat.Reserve(epInfo.NetworkID, epInfo.EndpointID, epInfo.SNATAddress, netInfo.InfraVNetCIDR)
+				if epInfo.IPAddress != "" && !d.snat.RuleExists(epInfo.IPAddress, epInfo.SNATAddress) {
+					if err := d.snat.ProgramRule(epInfo.IPAddress, epInfo.SNATAddress); err != nil {
+						d.logger.WithError(err).Warnf("Failed to reprogram SNAT rule for endpoint %s", epInfo.EndpointID)
+					}
+				}
+			}
 		}
 
-		// Store in memory map
-		key := fmt.Sprintf("%s:%s", epInfo.NetworkID, epInfo.EndpointID)
-		d.endpoints[key] = endpoint
+		d.reconcileEndpoint(epInfo, &report)
+	}
+
+	// Re-register every elastic IP allocation so a restarted plugin doesn't
+	// hand the same external address out to a second endpoint; the
+	// DNAT_and_SNAT rule and gateway LRP themselves are left alone here and
+	// get repaired the next time that endpoint Joins.
+	for _, eipInfo := range d.store.ListEIPs() {
+		d.eip.Reserve(eipInfo.NetworkID, eipInfo.EndpointID, eipInfo.V4Ip, eipInfo.CIDR)
 	}
 
+	// Same for transit network router port allocations, so connectToTransitNetwork
+	// doesn't hand out an address already in use by a surviving VPC router.
+	for _, portInfo := range d.store.ListTransitPorts() {
+		d.ipam.Reserve(&ipam.TransitAllocation{
+			TransitNetworkID: portInfo.TransitNetworkID,
+			Router:           portInfo.Router,
+			IP:               portInfo.IP,
+			MAC:              portInfo.MAC,
+			Subnets:          portInfo.Subnets,
+		})
+	}
+
+	d.garbageCollectOrphanedPorts(storedNetworks, endpoints, &report)
+
+	d.store.SetRecoveryReport(report)
+	d.logger.Infof("Recovery reconciliation: %d healthy, %d repaired, %d orphaned", report.Healthy, report.Repaired, report.Orphaned)
+	if len(report.MissingVeths) > 0 {
+		d.logger.Warnf("Recovery found %d endpoint(s) with no surviving veth: %s", len(report.MissingVeths), strings.Join(report.MissingVeths, ", "))
+	}
+
+	// d.ovn isn't initialized until the first network with ovn.switch is
+	// created (see CreateNetwork), so OVN-side reconciliation happens
+	// there instead of here - see reconcileWithOVN.
+
 	d.logger.Infof("Recovered %d networks and %d endpoints",
 		len(d.networks), len(d.endpoints))
 
 	return nil
 }
 
+// reconcileEndpoint checks whether a recovered endpoint's veth and OVS port
+// are still alive, re-adds a missing OVS port (vlan/trunk-mode networks have
+// no port to check), and tallies the result into report.
+func (d *Driver) reconcileEndpoint(epInfo *store.EndpointInfo, report *store.RecoveryReport) {
+	if epInfo.VethName == "" {
+		return // Never joined; nothing to reconcile yet
+	}
+
+	vethAlive := false
+	if _, err := d.ovs.GetLinkMAC(epInfo.VethName); err == nil {
+		vethAlive = true
+	}
+
+	netInfo, err := d.store.GetNetwork(epInfo.NetworkID)
+	if err != nil {
+		return
+	}
+
+	if netInfo.Mode == "vlan" || netInfo.Mode == "trunk" {
+		if vethAlive {
+			report.Healthy++
+			d.recordActiveEndpoint(report, epInfo)
+		} else {
+			d.logger.Warnf("Endpoint %s's VLAN sub-interface %s is gone; Docker will recreate it on next Join", epInfo.EndpointID, epInfo.VethName)
+			report.MissingVeths = append(report.MissingVeths, epInfo.EndpointID)
+		}
+		return
+	}
+
+	portAlive := false
+	if epInfo.PortName != "" {
+		if ports, err := d.ovs.ListPorts(netInfo.Bridge); err == nil {
+			for _, p := range ports {
+				if p == epInfo.PortName {
+					portAlive = true
+					break
+				}
+			}
+		}
+	}
+
+	switch {
+	case vethAlive && portAlive:
+		report.Healthy++
+		d.recordActiveEndpoint(report, epInfo)
+	case vethAlive && epInfo.PortName != "":
+		d.logger.Warnf("OVS port %s for endpoint %s is missing, re-adding it", epInfo.PortName, epInfo.EndpointID)
+		portOptions := map[string]string{
+			"external_ids:container_id": epInfo.EndpointID,
+			"external_ids:network_id":   epInfo.NetworkID,
+		}
+		if netInfo.TenantID != "" {
+			portOptions["external_ids:tenant_id"] = netInfo.TenantID
+		}
+		if netInfo.VLAN != 0 {
+			portOptions["tag"] = fmt.Sprintf("%d", netInfo.VLAN)
+		}
+		if err := d.ovs.AddPort(netInfo.Bridge, epInfo.PortName, portOptions); err != nil {
+			d.logger.WithError(err).Warnf("Failed to repair OVS port %s for endpoint %s", epInfo.PortName, epInfo.EndpointID)
+			return
+		}
+		report.Repaired++
+		d.recordActiveEndpoint(report, epInfo)
+	default:
+		d.logger.Warnf("Endpoint %s's veth %s is gone; Docker will recreate it on next Join", epInfo.EndpointID, epInfo.VethName)
+		report.MissingVeths = append(report.MissingVeths, epInfo.EndpointID)
+	}
+}
+
+// recordActiveEndpoint adds epInfo to report.ActiveEndpoints, libnetwork's
+// OptionActiveSandboxes equivalent for this plugin: endpoints Join can treat
+// as already provisioned instead of redoing work a restart didn't lose.
+func (d *Driver) recordActiveEndpoint(report *store.RecoveryReport, epInfo *store.EndpointInfo) {
+	if report.ActiveEndpoints == nil {
+		report.ActiveEndpoints = make(map[string]store.EndpointRuntimeState)
+	}
+	report.ActiveEndpoints[epInfo.EndpointID] = store.EndpointRuntimeState{
+		NetworkID:  epInfo.NetworkID,
+		EndpointID: epInfo.EndpointID,
+		VethName:   epInfo.VethName,
+		SandboxKey: epInfo.SandboxKey,
+	}
+}
+
+// garbageCollectOrphanedPorts removes OVS ports that belong to our networks
+// (external_ids:network_id matches) but reference no endpoint we have
+// persisted state for, e.g. left behind by a crash between AddPort and
+// persistEndpoint.
+func (d *Driver) garbageCollectOrphanedPorts(storedNetworks []*store.NetworkInfo, endpoints []*store.EndpointInfo, report *store.RecoveryReport) {
+	knownPorts := make(map[string]bool, len(endpoints))
+	for _, epInfo := range endpoints {
+		if epInfo.PortName != "" {
+			knownPorts[epInfo.PortName] = true
+		}
+	}
+
+	for _, netInfo := range storedNetworks {
+		if netInfo.Mode == "vlan" || netInfo.Mode == "trunk" {
+			continue
+		}
+
+		ports, err := d.ovs.ListPorts(netInfo.Bridge)
+		if err != nil {
+			continue
+		}
+
+		for _, port := range ports {
+			if port == netInfo.Bridge || knownPorts[port] {
+				continue
+			}
+
+			info, err := d.ovs.GetPortInfo(port)
+			if err != nil || info["external_id:network_id"] != netInfo.ID {
+				continue // Not ours to manage
+			}
+
+			d.logger.Warnf("Garbage-collecting orphaned OVS port %s on bridge %s (no matching endpoint)", port, netInfo.Bridge)
+			if err := d.ovs.DeletePort(netInfo.Bridge, port); err != nil {
+				d.logger.WithError(err).Warnf("Failed to garbage-collect orphaned port %s", port)
+				continue
+			}
+			report.Orphaned++
+			report.OrphanedVeths = append(report.OrphanedVeths, port)
+		}
+	}
+}
+
+// reconcileWithOVN cross-checks persisted endpoints against OVN's own view
+// of logical ports, now that d.ovn is available - recoverState can't do
+// this itself since d.ovn isn't initialized until the first network with an
+// ovn.switch option is created. Drift is logged, not fatal: an operator can
+// inspect it via the store's RecoveryReport/Reconcile and act on it.
+func (d *Driver) reconcileWithOVN() {
+	if err := d.store.Reconcile(context.Background(), d.ovn); err != nil {
+		d.logger.WithError(err).Warn("Store/OVN reconciliation found drift")
+	}
+	if err := d.reconcileFinalizersLocked(); err != nil {
+		d.logger.WithError(err).Warn("Failed to reconcile OVN resource finalizers")
+	}
+	d.reconcileGatewayModes()
+}
+
+// reconcileGatewayModes re-asserts each transit network's configured
+// ovn.external_gateway_mode against OVN's actual gateway router ports, so a
+// centralized<->distributed flip (caught here since d.ovn wasn't connected
+// yet when recoverState loaded the network) tears down the old mode's ports
+// and builds the new mode's instead of leaving both side by side.
+func (d *Driver) reconcileGatewayModes() {
+	const gatewayRouter = "lr-gateway"
+
+	for _, net := range d.networks {
+		if net.Options["ovn.role"] != "transit" || net.OVNSwitch == "" {
+			continue
+		}
+
+		mode := net.Options["ovn.external_gateway_mode"]
+		if mode == "" {
+			mode = "centralized"
+		}
+		nodes := parseGatewayNodes(net.Options["ovn.external_gateway_nodes"])
+
+		centralizedPort := fmt.Sprintf("rp-%s-%s", gatewayRouter, net.OVNSwitch)
+		hasCentralizedPort := d.ovn.PortExists(centralizedPort)
+
+		switch mode {
+		case "distributed":
+			if !hasCentralizedPort {
+				continue // already migrated (or never centralized); nothing to tear down
+			}
+			d.logger.Infof("Migrating transit network %s from centralized to distributed gateway mode", net.ID)
+			d.ovn.DeleteLogicalPort(fmt.Sprintf("sp-%s-%s", net.OVNSwitch, gatewayRouter))
+			d.ovn.DeleteLogicalRouterPort(centralizedPort)
+			if err := d.createDistributedGatewayPorts(gatewayRouter, net.OVNSwitch, nodes); err != nil {
+				d.logger.WithError(err).Warnf("Failed to migrate transit network %s to distributed gateway mode", net.ID)
+			}
+		default:
+			migrated := false
+			for _, node := range nodes {
+				distributedPort := fmt.Sprintf("rp-%s-%s-%s", gatewayRouter, net.OVNSwitch, node.Chassis)
+				if !d.ovn.PortExists(distributedPort) {
+					continue
+				}
+				migrated = true
+				d.ovn.DeleteLogicalPort(fmt.Sprintf("sp-%s-%s-%s", net.OVNSwitch, gatewayRouter, node.Chassis))
+				d.ovn.DeleteLogicalRouterPort(distributedPort)
+			}
+			if migrated {
+				d.logger.Infof("Migrating transit network %s from distributed to centralized gateway mode", net.ID)
+				if err := d.createCentralizedGatewayPort(gatewayRouter, net.OVNSwitch, net, nodes); err != nil {
+					d.logger.WithError(err).Warnf("Failed to migrate transit network %s to centralized gateway mode", net.ID)
+				}
+			}
+		}
+	}
+}
+
 // GetCapabilities returns the driver capabilities
 func (d *Driver) GetCapabilities() (*dnetwork.CapabilitiesResponse, error) {
 	d.logger.Debug("GetCapabilities called")
@@ -485,6 +791,18 @@ func (d *Driver) CreateNetwork(req *dnetwork.CreateNetworkRequest) error {
 		case "mirror.dest":
 			netConfig.MirrorDest = strValue
 			d.logger.Infof("Set mirror dest to: %s", strValue)
+		case "enable_snat_on_host":
+			netConfig.EnableSNATOnHost = strValue == "true"
+			d.logger.Infof("Set enable_snat_on_host to: %s", strValue)
+		case "infra_vnet_cidr":
+			netConfig.InfraVNetCIDR = strValue
+			d.logger.Infof("Set infra_vnet_cidr to: %s", strValue)
+		case "mode":
+			netConfig.Mode = strValue
+			d.logger.Infof("Set mode to: %s", strValue)
+		case "parent":
+			netConfig.Parent = strValue
+			d.logger.Infof("Set parent to: %s", strValue)
 		default:
 			netConfig.Options[key] = strValue
 			d.logger.Debugf("Stored option %s = %s", key, strValue)
@@ -501,6 +819,12 @@ func (d *Driver) CreateNetwork(req *dnetwork.CreateNetworkRequest) error {
 		netConfig.IPv6Data = req.IPv6Data[0]
 	}
 
+	// "vlan"/"trunk" mode attaches containers to a plain 802.1Q sub-interface
+	// of a host NIC instead of an OVS bridge, so it skips OVS/OVN entirely.
+	if netConfig.Mode == "vlan" || netConfig.Mode == "trunk" {
+		return d.createVlanNetwork(req, netConfig)
+	}
+
 	// Create or verify the OVS bridge exists
 	if err := d.ovs.EnsureBridge(netConfig.Bridge); err != nil {
 		return fmt.Errorf("failed to ensure bridge %s: %w", netConfig.Bridge, err)
@@ -548,6 +872,7 @@ func (d *Driver) CreateNetwork(req *dnetwork.CreateNetworkRequest) error {
 		}
 		d.ovn = ovnClient
 		d.logger.Infof("Connected to OVN at %s", nbConn)
+		d.reconcileWithOVN()
 	} else {
 		d.logger.Infof("Using existing OVN client")
 	}
@@ -562,60 +887,102 @@ func (d *Driver) CreateNetwork(req *dnetwork.CreateNetworkRequest) error {
 	if err := d.ovn.CreateLogicalSwitch(netConfig.OVNSwitch, ovnOptions); err != nil {
 		return fmt.Errorf("failed to create OVN logical switch: %w", err)
 	}
+	if err := d.store.AddFinalizer(store.FinalizerSwitch, netConfig.OVNSwitch, req.NetworkID); err != nil {
+		d.logger.WithError(err).Warnf("Failed to add finalizer for switch %s", netConfig.OVNSwitch)
+	}
 
-	// If DHCP is enabled, create DHCP options
-	if netConfig.Options["dhcp"] == "ovn" && netConfig.IPv4Data != nil {
-		dhcpOpts := map[string]string{
-			"lease_time": "3600",
-			"router":     netConfig.IPv4Data.Gateway,
-		}
-		if dns := netConfig.Options["dns_server"]; dns != "" {
-			dhcpOpts["dns_server"] = dns
-		}
+	// Declarative ACLs: one Port_Group per name in "ovn.security_groups",
+	// with ingress/egress rules compiled from that group's
+	// ovn.acl.ingress.<group>/ovn.acl.egress.<group> options. Join adds each
+	// endpoint's logical port to the groups listed in its own
+	// "security_groups" option.
+	if err := d.reconcileSecurityGroups(req.NetworkID, netConfig); err != nil {
+		d.logger.WithError(err).Warnf("Failed to reconcile security groups for network %s", req.NetworkID)
+	}
 
-		dhcpUUID, err := d.ovn.CreateDHCPOptions(
-			netConfig.IPv4Data.Pool,
-			"02:00:00:00:00:01", // Default server MAC
-			netConfig.IPv4Data.Gateway,
-			dhcpOpts,
-		)
-		if err != nil {
-			d.logger.Warnf("Failed to create OVN DHCP options: %v", err)
-		} else {
-			netConfig.Options["dhcp_uuid"] = dhcpUUID
-		}
+	// Declarative load balancers: one OVN Load_Balancer per name in
+	// "ovn.lb.<name>.vip"/".backends"/".protocol", attached to the switch so
+	// sibling endpoints can reach the VIP directly.
+	if err := d.reconcileLoadBalancers(req.NetworkID, netConfig); err != nil {
+		d.logger.WithError(err).Warnf("Failed to reconcile load balancers for network %s", req.NetworkID)
+	}
+
+	// Microsegmentation: a network-wide Port_Group every endpoint is
+	// unconditionally added to on Join (unlike the opt-in named security
+	// groups above), with label-selector ACL rules compiled from
+	// "ovn.policy"/"ovn.policy_file" onto it. See pkg/driver/policy.
+	if err := d.reconcileNetworkPolicy(req.NetworkID, netConfig); err != nil {
+		d.logger.WithError(err).Warnf("Failed to reconcile network policy for network %s", req.NetworkID)
+	}
+
+	// Native OVN DHCP: one DHCP_Options row per pool from "ovn.dhcp4"/
+	// "ovn.dhcp6", so containers get a lease from OVN's own responder
+	// instead of Docker's embedded one. Re-run on every CreateNetwork so an
+	// options change (e.g. a new ovn.dhcp4.mtu) gets picked up without
+	// tearing down the network - see reconcileDHCPOptions.
+	if err := d.reconcileDHCPOptions(req.NetworkID, netConfig); err != nil {
+		d.logger.WithError(err).Warnf("Failed to reconcile DHCP options for network %s", req.NetworkID)
 	}
 
 	// Encapsulation is configured at the chassis level via orchestrator setup-chassis command
 	// The Docker plugin doesn't need to handle this
 
-	// Create or connect to L3 router if specified
-	if netConfig.OVNRouter != "" && netConfig.IPv4Data != nil {
+	// Create or connect to L3 router if specified. "ovn.router.gateway"
+	// lets a network supply its own LRP networks instead of Docker's IPAM
+	// gateway, so a router can be set up on a network with no IPv4Data at all.
+	routerGatewayOpt := netConfig.Options["ovn.router.gateway"]
+	if netConfig.OVNRouter != "" && (netConfig.IPv4Data != nil || routerGatewayOpt != "") {
 		d.logger.Infof("Setting up L3 gateway with router: %s", netConfig.OVNRouter)
 
-		// Create router if it doesn't exist
-		routerOpts := make(map[string]string)
-		routerOpts["network_id"] = req.NetworkID
-		if netConfig.TenantID != "" {
-			routerOpts["tenant_id"] = netConfig.TenantID
+		// "ovn.router.auto_create=false" requires the router to already
+		// exist - CreateLogicalRouter's own create-or-find is skipped so a
+		// typo'd router name fails loudly instead of silently creating one.
+		if netConfig.Options["ovn.router.auto_create"] == "false" {
+			exists, err := d.ovn.LogicalRouterExists(netConfig.OVNRouter)
+			if err != nil {
+				return fmt.Errorf("failed to check logical router %s: %w", netConfig.OVNRouter, err)
+			}
+			if !exists {
+				return fmt.Errorf("logical router %s does not exist and ovn.router.auto_create is false", netConfig.OVNRouter)
+			}
+		} else {
+			routerOpts := make(map[string]string)
+			routerOpts["network_id"] = req.NetworkID
+			if netConfig.TenantID != "" {
+				routerOpts["tenant_id"] = netConfig.TenantID
+			}
+			if err := d.ovn.CreateLogicalRouter(netConfig.OVNRouter, routerOpts); err != nil {
+				return fmt.Errorf("failed to create logical router %s: %w", netConfig.OVNRouter, err)
+			}
 		}
-
-		if err := d.ovn.CreateLogicalRouter(netConfig.OVNRouter, routerOpts); err != nil {
-			return fmt.Errorf("failed to create logical router %s: %w", netConfig.OVNRouter, err)
+		if err := d.store.AddFinalizer(store.FinalizerRouter, netConfig.OVNRouter, req.NetworkID); err != nil {
+			d.logger.WithError(err).Warnf("Failed to add finalizer for router %s", netConfig.OVNRouter)
 		}
 
 		// Create router port - must be unique per switch
 		routerPort := fmt.Sprintf("rp-%s", netConfig.OVNSwitch)
 		routerMAC := "02:00:00:00:01:01" // Default router MAC
-		// The gateway already includes CIDR notation from Docker
-		routerNetwork := netConfig.IPv4Data.Gateway
+		var routerNetworks []string
+		if routerGatewayOpt != "" {
+			for _, gw := range strings.Split(routerGatewayOpt, ",") {
+				if gw = strings.TrimSpace(gw); gw != "" {
+					routerNetworks = append(routerNetworks, gw)
+				}
+			}
+		} else {
+			// The gateway already includes CIDR notation from Docker
+			routerNetworks = []string{netConfig.IPv4Data.Gateway}
+		}
+		if netConfig.IPv6Data != nil {
+			routerNetworks = append(routerNetworks, netConfig.IPv6Data.Gateway)
+		}
 
 		// Create the router port
 		if err := d.ovn.CreateLogicalRouterPort(
 			netConfig.OVNRouter,
 			routerPort,
 			routerMAC,
-			[]string{routerNetwork},
+			routerNetworks,
 		); err != nil {
 			return fmt.Errorf("failed to create router port: %w", err)
 		}
@@ -638,10 +1005,28 @@ func (d *Driver) CreateNetwork(req *dnetwork.CreateNetworkRequest) error {
 		}
 		d.logger.Infof("Connected router %s to switch %s", netConfig.OVNRouter, netConfig.OVNSwitch)
 
+		// Rank this switch's router port's failover across a named set of
+		// chassis via HA_Chassis_Group, distinct from the gateway-chassis
+		// ranking createCentralizedGatewayPort applies (which lives on the
+		// Logical_Router_Port, not this Logical_Switch_Port).
+		if err := d.reconcileHAChassisGroup(switchPort, netConfig); err != nil {
+			return fmt.Errorf("failed to reconcile HA chassis group: %w", err)
+		}
+
+		// U2O (underlay-to-overlay) interconnection: give the switch a
+		// localnet port pinned to a physical network, and the router a
+		// dedicated LRP onto it, so the overlay subnet can reach a physical
+		// VLAN directly instead of only ever NATing through a gateway router.
+		if isU2O(netConfig) {
+			if err := d.setupU2OInterconnection(req.NetworkID, netConfig); err != nil {
+				return fmt.Errorf("failed to set up u2o interconnection: %w", err)
+			}
+		}
+
 		// Connect to transit network if specified
 		if transitNet := netConfig.Options["ovn.transit_network"]; transitNet != "" {
 			d.logger.Infof("Connecting to transit network: %s", transitNet)
-			if err := d.connectToTransitNetwork(netConfig.OVNRouter, transitNet); err != nil {
+			if err := d.connectToTransitNetwork(netConfig.OVNRouter, transitNet, netConfig); err != nil {
 				return fmt.Errorf("failed to connect to transit network: %w", err)
 			}
 		}
@@ -652,6 +1037,42 @@ func (d *Driver) CreateNetwork(req *dnetwork.CreateNetworkRequest) error {
 			// Implementation depends on your network topology
 			d.logger.Infof("External gateway configured: %s", extGW)
 		}
+
+		// Peer this router directly to another logical router via a
+		// dedicated LRP-to-LRP link, for a static two-router topology
+		// declared by "ovn.router.peer" - distinct from the dynamic VPC
+		// transit network above, which goes through a shared transit switch.
+		if peerRouter := netConfig.Options["ovn.router.peer"]; peerRouter != "" {
+			if err := d.peerLogicalRouters(netConfig.OVNRouter, peerRouter); err != nil {
+				d.logger.WithError(err).Warnf("Failed to peer router %s with %s", netConfig.OVNRouter, peerRouter)
+			}
+		}
+
+		// Install a declarative SNAT rule, "ovn.snat=<subnet>-><external-ip>",
+		// translating this subnet's traffic to externalIP as it leaves the
+		// router - the general-purpose counterpart to the per-endpoint
+		// elastic IP DNAT+SNAT rules programmed in programEIP.
+		if snatOpt := netConfig.Options["ovn.snat"]; snatOpt != "" {
+			if parts := strings.SplitN(snatOpt, "->", 2); len(parts) == 2 {
+				subnet, externalIP := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+				if err := d.ovn.AddSNAT(netConfig.OVNRouter, subnet, externalIP); err != nil {
+					d.logger.WithError(err).Warnf("Failed to add SNAT rule for network %s", req.NetworkID)
+				}
+			} else {
+				d.logger.Warnf("Invalid ovn.snat option %q, expected <subnet>-><external-ip>", snatOpt)
+			}
+		}
+	}
+
+	// Attach this switch to a distributed router shared across tenant
+	// switches, if requested, instead of (or alongside) a dedicated
+	// ovn.router. The DR itself is created once, on the first switch that
+	// references it; DeleteNetwork tears it down once the last switch
+	// detaches.
+	if drName := netConfig.Options["ovn.distributed_router"]; drName != "" && d.ovn != nil {
+		if err := d.attachToDistributedRouter(drName, netConfig); err != nil {
+			return fmt.Errorf("failed to attach network to distributed router %s: %w", drName, err)
+		}
 	}
 
 	// Store the network configuration
@@ -664,14 +1085,16 @@ func (d *Driver) CreateNetwork(req *dnetwork.CreateNetworkRequest) error {
 	}
 
 	storeInfo := &store.NetworkInfo{
-		ID:        req.NetworkID,
-		Name:      req.NetworkID, // Docker doesn't provide a separate name
-		Bridge:    netConfig.Bridge,
-		VLAN:      vlan,
-		TenantID:  netConfig.TenantID,
-		OVNSwitch: netConfig.OVNSwitch,
-		OVNRouter: netConfig.OVNRouter,
-		Options:   netConfig.Options,
+		ID:               req.NetworkID,
+		Name:             req.NetworkID, // Docker doesn't provide a separate name
+		Bridge:           netConfig.Bridge,
+		VLAN:             vlan,
+		TenantID:         netConfig.TenantID,
+		OVNSwitch:        netConfig.OVNSwitch,
+		OVNRouter:        netConfig.OVNRouter,
+		EnableSNATOnHost: netConfig.EnableSNATOnHost,
+		InfraVNetCIDR:    netConfig.InfraVNetCIDR,
+		Options:          netConfig.Options,
 	}
 
 	if req.IPv4Data != nil && len(req.IPv4Data) > 0 {
@@ -688,734 +1111,3074 @@ func (d *Driver) CreateNetwork(req *dnetwork.CreateNetworkRequest) error {
 	return nil
 }
 
-// AllocateNetwork allocates resources for a network
-func (d *Driver) AllocateNetwork(req *dnetwork.AllocateNetworkRequest) (*dnetwork.AllocateNetworkResponse, error) {
-	d.logger.WithField("network_id", req.NetworkID).Debug("AllocateNetwork called")
-	// No special allocation needed for OVS
-	return &dnetwork.AllocateNetworkResponse{}, nil
-}
+// defaultACLLogMeter is the well-known meter name shared by every network's
+// logged ACLs, created on first use rather than per-network or per-group -
+// the rate limit is a cluster-wide log-volume budget, not a per-group one.
+const defaultACLLogMeter = "acl-log"
+
+// defaultACLLogRate is the default packets-per-second budget for
+// defaultACLLogMeter.
+const defaultACLLogRate = 100
+
+// reconcileSecurityGroups compiles netConfig's "ovn.security_groups" option
+// into one OVN Port_Group per named group, with ACLs from that group's
+// ovn.acl.ingress.<group>/ovn.acl.egress.<group> options. Each group's
+// policy hash is compared against the PolicyInfo persisted in the store, so
+// a restart that finds the ACLs already programmed with an unchanged hash
+// skips reprogramming them.
+func (d *Driver) reconcileSecurityGroups(networkID string, netConfig *types.Network) error {
+	if d.ovn == nil {
+		return nil
+	}
 
-// DeleteNetwork deletes a network
-func (d *Driver) DeleteNetwork(req *dnetwork.DeleteNetworkRequest) error {
-	d.Lock()
-	defer d.Unlock()
+	sgOpt := netConfig.Options["ovn.security_groups"]
+	if sgOpt == "" {
+		return nil
+	}
 
-	d.logger.WithField("network_id", req.NetworkID).Info("DeleteNetwork called")
+	meterEnsured := false
 
-	net, exists := d.networks[req.NetworkID]
-	if !exists {
-		d.logger.Warnf("Network %s not found", req.NetworkID)
-		return nil // Idempotent
-	}
+	for _, sg := range strings.Split(sgOpt, ",") {
+		sg = strings.TrimSpace(sg)
+		if sg == "" {
+			continue
+		}
 
-	// Check if there are any endpoints still attached
-	for _, ep := range d.endpoints {
-		if ep.NetworkID == req.NetworkID {
-			return fmt.Errorf("network %s still has active endpoints", req.NetworkID)
+		ingress, err := ovn.ParseACLRules(netConfig.Options[fmt.Sprintf("ovn.acl.ingress.%s", sg)])
+		if err != nil {
+			return fmt.Errorf("security group %s: %w", sg, err)
+		}
+		egress, err := ovn.ParseACLRules(netConfig.Options[fmt.Sprintf("ovn.acl.egress.%s", sg)])
+		if err != nil {
+			return fmt.Errorf("security group %s: %w", sg, err)
 		}
-	}
 
-	// In a multi-host environment, OVN logical switches and routers are shared
-	// resources that may have containers from other hosts. We should NOT delete them.
-	// The orchestrator or admin should manage the lifecycle of these shared resources.
-	if net.OVNSwitch != "" && d.ovn != nil {
-		d.logger.Infof("Network %s removed, keeping OVN switch %s (shared resource)", req.NetworkID, net.OVNSwitch)
-	}
+		policy := &ovn.SecurityGroupPolicy{Name: sg, Ingress: ingress, Egress: egress}
+		hash := policy.Hash()
 
-	// Clean up any OVS-specific resources if needed
-	// For now, we keep the bridge as it might be shared
+		policyID := fmt.Sprintf("%s:%s", networkID, sg)
+		existing, err := d.store.GetPolicy(policyID)
+		if err == nil && existing.Hash == hash {
+			d.logger.Debugf("Security group %s policy unchanged, skipping reprogramming", sg)
+			continue
+		}
 
-	delete(d.networks, req.NetworkID)
+		pgName := ovn.PortGroupName(networkID, sg)
+		if err := d.ovn.CreatePortGroup(pgName); err != nil {
+			return fmt.Errorf("security group %s: %w", sg, err)
+		}
 
-	// Remove from store
-	if err := d.store.DeleteNetwork(req.NetworkID); err != nil {
-		d.logger.WithError(err).Warn("Failed to remove network from store")
-		// Non-fatal: continue even if we can't remove from store
-	}
+		needsLogging := false
+		for _, rule := range append(append([]ovn.ACLRule{}, ingress...), egress...) {
+			if rule.Log {
+				needsLogging = true
+				break
+			}
+		}
+		meterName := ""
+		if needsLogging {
+			if !meterEnsured {
+				if err := d.ovn.EnsureLogMeter(defaultACLLogMeter, defaultACLLogRate); err != nil {
+					d.logger.WithError(err).Warn("Failed to create ACL log meter")
+				}
+				meterEnsured = true
+			}
+			meterName = defaultACLLogMeter
+		}
 
-	d.logger.Infof("Network %s deleted", req.NetworkID)
-	return nil
-}
+		if err := d.ovn.ApplyPolicy(pgName, policy, meterName); err != nil {
+			return fmt.Errorf("security group %s: %w", sg, err)
+		}
+
+		if err := d.store.SavePolicy(&store.PolicyInfo{
+			ID:            policyID,
+			NetworkID:     networkID,
+			SecurityGroup: sg,
+			Hash:          hash,
+		}); err != nil {
+			d.logger.WithError(err).Warnf("Failed to persist policy hash for security group %s", sg)
+		}
+
+		d.logger.Infof("Applied security group %s (port group %s) to network %s", sg, pgName, networkID)
+	}
 
-// FreeNetwork frees network resources
-func (d *Driver) FreeNetwork(req *dnetwork.FreeNetworkRequest) error {
-	d.logger.WithField("network_id", req.NetworkID).Debug("FreeNetwork called")
-	// No special cleanup needed
 	return nil
 }
 
-// CreateEndpoint creates a new endpoint
-func (d *Driver) CreateEndpoint(req *dnetwork.CreateEndpointRequest) (*dnetwork.CreateEndpointResponse, error) {
-	d.Lock()
-	defer d.Unlock()
-
-	d.logger.WithFields(logrus.Fields{
-		"network_id":  req.NetworkID,
-		"endpoint_id": req.EndpointID,
-		"interface":   req.Interface,
-		"options":     req.Options,
-	}).Info("CreateEndpoint called")
+// defaultNetworkPolicyGroup is the sentinel store.PolicyInfo.SecurityGroup
+// value used to persist the network-wide microsegmentation policy's hash
+// (see reconcileNetworkPolicy) in the same policies/ store prefix as named
+// security groups, without colliding with an actual group name.
+const defaultNetworkPolicyGroup = "__network__"
+
+// dhcpv4PolicyGroup/dhcpv6PolicyGroup are the sentinel store.PolicyInfo.
+// SecurityGroup values used to persist a network's ovn.dhcp4.*/ovn.dhcp6.*
+// option hash (see reconcileDHCPOptions), in the same policies/ store
+// prefix as defaultNetworkPolicyGroup and named security groups.
+const (
+	dhcpv4PolicyGroup = "__dhcp4__"
+	dhcpv6PolicyGroup = "__dhcp6__"
+)
 
-	net, exists := d.networks[req.NetworkID]
-	if !exists {
-		return nil, fmt.Errorf("network %s not found", req.NetworkID)
+// reconcileNetworkPolicy ensures networkID's network-wide Port_Group (see
+// ovn.NetworkPortGroupName) exists, so Join can unconditionally add every
+// endpoint to it, and compiles any rules declared via
+// "ovn.policy"/"ovn.policy_file" onto it - kube-ovn-style label-selector
+// microsegmentation (see pkg/driver/policy), distinct from
+// reconcileSecurityGroups' opt-in named groups. A rule's selector gets its
+// Address_Set created up front so the ACL has something to reference even
+// before any endpoint carries that label. The compiled rule set's hash is
+// compared against the PolicyInfo persisted in the store, mirroring
+// reconcileSecurityGroups, so an unchanged policy is skipped on restart.
+func (d *Driver) reconcileNetworkPolicy(networkID string, netConfig *types.Network) error {
+	if d.ovn == nil {
+		return nil
 	}
 
-	ep := &types.Endpoint{
-		ID:        req.EndpointID,
-		NetworkID: req.NetworkID,
-		Network:   net,
+	pgName := ovn.NetworkPortGroupName(networkID)
+	if err := d.ovn.CreatePortGroup(pgName); err != nil {
+		return fmt.Errorf("network policy: %w", err)
 	}
 
-	// Store MAC address if provided
-	if req.Interface != nil && req.Interface.MacAddress != "" {
-		ep.MacAddress = req.Interface.MacAddress
+	rules, err := policy.ParseRules(netConfig.Options["ovn.policy"], netConfig.Options["ovn.policy_file"])
+	if err != nil {
+		return fmt.Errorf("network policy: %w", err)
 	}
-
-	// Store IPv4 address if provided
-	if req.Interface != nil && req.Interface.Address != "" {
-		ep.IPv4Address = req.Interface.Address
+	if len(rules) == 0 {
+		return nil
 	}
 
-	// Store IPv6 address if provided
-	if req.Interface != nil && req.Interface.AddressIPv6 != "" {
-		ep.IPv6Address = req.Interface.AddressIPv6
+	hash := policy.Hash(rules)
+	policyID := fmt.Sprintf("%s:%s", networkID, defaultNetworkPolicyGroup)
+	if existing, err := d.store.GetPolicy(policyID); err == nil && existing.Hash == hash {
+		d.logger.Debugf("Network policy for %s unchanged, skipping reprogramming", networkID)
+		return nil
 	}
 
-	// Process endpoint options
-	ep.Options = make(map[string]string)
-	for key, value := range req.Options {
-		strValue, ok := value.(string)
-		if !ok {
-			strValue = fmt.Sprintf("%v", value)
+	meterName := ""
+	for _, r := range rules {
+		if r.Selector != "" {
+			if err := d.ovn.CreateAddressSet(ovn.AddressSetName(networkID, r.Selector)); err != nil {
+				return fmt.Errorf("network policy: %w", err)
+			}
+		}
+		if r.Log && meterName == "" {
+			if err := d.ovn.EnsureLogMeter(defaultACLLogMeter, defaultACLLogRate); err != nil {
+				d.logger.WithError(err).Warn("Failed to create ACL log meter")
+			}
+			meterName = defaultACLLogMeter
 		}
-		ep.Options[key] = strValue
 	}
 
-	// Store the endpoint
-	d.endpoints[req.EndpointID] = ep
-
-	// Persist to store
-	storeEp := &store.EndpointInfo{
-		ID:         fmt.Sprintf("%s:%s", req.NetworkID, req.EndpointID),
-		NetworkID:  req.NetworkID,
-		EndpointID: req.EndpointID,
-		VethName:   ep.VethName,
-		IPAddress:  ep.IPv4Address,
-		MACAddress: ep.MacAddress,
-		Gateway:    "", // Will be set later if needed
-		OVNPort:    ep.Options["ovn_port"],
+	if err := policy.SetACL(d.ovn, networkID, rules, meterName); err != nil {
+		return fmt.Errorf("network policy: %w", err)
 	}
 
-	if err := d.store.SaveEndpoint(storeEp); err != nil {
-		d.logger.WithError(err).Warn("Failed to persist endpoint to store")
-		// Non-fatal: continue even if we can't persist
+	if err := d.store.SavePolicy(&store.PolicyInfo{
+		ID:            policyID,
+		NetworkID:     networkID,
+		SecurityGroup: defaultNetworkPolicyGroup,
+		Hash:          hash,
+	}); err != nil {
+		d.logger.WithError(err).Warnf("Failed to persist network policy hash for network %s", networkID)
 	}
 
-	d.logger.Infof("Endpoint %s created for network %s", req.EndpointID, req.NetworkID)
+	d.logger.Infof("Applied network policy (port group %s) to network %s", pgName, networkID)
+	return nil
+}
 
-	resp := &dnetwork.CreateEndpointResponse{}
+// lbSpec is one "ovn.lb.<name>" load balancer's options, collected from
+// netConfig.Options by parseLBOptions before being compiled into an OVN
+// Load_Balancer by reconcileLoadBalancers.
+type lbSpec struct {
+	vip      string
+	backends []string
+	protocol string
+}
 
-	// If no MAC was provided, we'll generate one when joining
-	if ep.MacAddress != "" {
-		resp.Interface = &dnetwork.EndpointInterface{
-			MacAddress: ep.MacAddress,
+// parseLBOptions groups every "ovn.lb.<name>.vip"/".backends"/".protocol"
+// option in options by <name>, the same option-namespacing scheme
+// reconcileSecurityGroups uses for "ovn.acl.ingress.<group>".
+func parseLBOptions(options map[string]string) map[string]*lbSpec {
+	lbs := make(map[string]*lbSpec)
+	for key, value := range options {
+		if !strings.HasPrefix(key, "ovn.lb.") {
+			continue
 		}
-	}
+		rest := strings.TrimPrefix(key, "ovn.lb.")
+		dot := strings.LastIndex(rest, ".")
+		if dot < 0 {
+			continue
+		}
+		name, field := rest[:dot], rest[dot+1:]
 
-	return resp, nil
-}
+		spec := lbs[name]
+		if spec == nil {
+			spec = &lbSpec{}
+			lbs[name] = spec
+		}
+		switch field {
+		case "vip":
+			spec.vip = value
+		case "protocol":
+			spec.protocol = value
+		case "backends":
+			for _, b := range strings.Split(value, ",") {
+				if b = strings.TrimSpace(b); b != "" {
+					spec.backends = append(spec.backends, b)
+				}
+			}
+		}
+	}
+	return lbs
+}
 
-// DeleteEndpoint deletes an endpoint
-func (d *Driver) DeleteEndpoint(req *dnetwork.DeleteEndpointRequest) error {
-	d.Lock()
-	defer d.Unlock()
+// reconcileLoadBalancers compiles netConfig's "ovn.lb.<name>" options (see
+// parseLBOptions) into one OVN Load_Balancer per name, attached to the
+// network's switch so endpoints on it can reach the VIP directly, and to
+// its router for north-south reachability. Each load balancer's VIP ->
+// backend map is persisted as a store.LBInfo so Join/Leave can add/remove a
+// dynamic member's address (see "lb_member") without losing the
+// statically declared backends.
+func (d *Driver) reconcileLoadBalancers(networkID string, netConfig *types.Network) error {
+	if d.ovn == nil {
+		return nil
+	}
 
-	d.logger.WithFields(logrus.Fields{
-		"network_id":  req.NetworkID,
-		"endpoint_id": req.EndpointID,
-	}).Info("DeleteEndpoint called")
+	for name, spec := range parseLBOptions(netConfig.Options) {
+		if spec.vip == "" {
+			d.logger.Warnf("Load balancer %s has no ovn.lb.%s.vip, skipping", name, name)
+			continue
+		}
 
-	ep, exists := d.endpoints[req.EndpointID]
-	if !exists {
-		d.logger.Warnf("Endpoint %s not found", req.EndpointID)
-		return nil // Idempotent
+		lbID := fmt.Sprintf("%s:%s", networkID, name)
+		lbInfo, err := d.store.GetLB(lbID)
+		if err != nil {
+			lbName := fmt.Sprintf("lb-%s-%s", networkID, name)
+			uuid, err := d.ovn.CreateLoadBalancer(lbName, spec.protocol)
+			if err != nil {
+				return fmt.Errorf("load balancer %s: %w", name, err)
+			}
+			lbInfo = &store.LBInfo{
+				ID:        lbID,
+				NetworkID: networkID,
+				Name:      name,
+				UUID:      uuid,
+				Protocol:  spec.protocol,
+				VIPs:      make(map[string][]string),
+			}
+		}
+
+		backends := lbInfo.VIPs[spec.vip]
+		for _, b := range spec.backends {
+			if !stringSliceContains(backends, b) {
+				backends = append(backends, b)
+			}
+		}
+		lbInfo.VIPs[spec.vip] = backends
+
+		if len(backends) > 0 {
+			if err := d.ovn.AddLBVIP(lbInfo.UUID, spec.vip, backends); err != nil {
+				return fmt.Errorf("load balancer %s: %w", name, err)
+			}
+		}
+
+		if err := d.ovn.AttachLBToSwitch(lbInfo.UUID, netConfig.OVNSwitch); err != nil {
+			return fmt.Errorf("load balancer %s: %w", name, err)
+		}
+		if netConfig.OVNRouter != "" {
+			if err := d.ovn.AttachLBToRouter(lbInfo.UUID, netConfig.OVNRouter); err != nil {
+				d.logger.WithError(err).Warnf("Failed to attach load balancer %s to router %s", name, netConfig.OVNRouter)
+			}
+		}
+
+		if err := d.store.SaveLB(lbInfo); err != nil {
+			d.logger.WithError(err).Warnf("Failed to persist load balancer %s", name)
+		}
+
+		d.logger.Infof("Applied load balancer %s (%s) to network %s", name, lbInfo.UUID, networkID)
 	}
 
-	// Clean up OVN logical port if it exists
-	if ep.Options != nil && ep.Options["ovn_port"] != "" && d.ovn != nil {
-		logicalPort := ep.Options["ovn_port"]
-		if err := d.ovn.DeleteLogicalPort(logicalPort); err != nil {
-			d.logger.WithError(err).Warnf("Failed to delete OVN logical port %s", logicalPort)
+	return nil
+}
+
+// reconcileDHCPOptions compiles netConfig's "ovn.dhcp4"/"ovn.dhcp6" options
+// into a DHCP_Options row per pool, so OVN's own DHCP responder hands out
+// leases instead of Docker's embedded one - SetPortDHCP/SetPortDHCPv6 then
+// point each endpoint's logical port at the stored UUID when it joins (see
+// Join). Each side's option hash is compared against the
+// PolicyInfo persisted in the store, mirroring
+// reconcileSecurityGroups/reconcileNetworkPolicy, so a network whose
+// ovn.dhcp4.*/ovn.dhcp6.* options haven't changed since the DHCP_Options
+// row was last written skips rewriting it; one that has gets its existing
+// row rewritten in place via ovn.UpdateDHCPOptions/UpdateDHCPv6Options
+// rather than deleted and recreated, so it's never left dangling from
+// ports that already reference it.
+func (d *Driver) reconcileDHCPOptions(networkID string, netConfig *types.Network) error {
+	if d.ovn == nil {
+		return nil
+	}
+
+	if netConfig.Options["ovn.dhcp4"] == "true" && netConfig.IPv4Data != nil {
+		router := netConfig.Options["ovn.dhcp4.router"]
+		if router == "" {
+			router = netConfig.IPv4Data.Gateway
+		}
+		leaseTime := netConfig.Options["ovn.dhcp4.lease_time"]
+		if leaseTime == "" {
+			leaseTime = "3600"
+		}
+		opts := ovn.DHCPv4Options{
+			Router:    router,
+			DNSServer: netConfig.Options["ovn.dhcp4.dns_server"],
+			LeaseTime: leaseTime,
+			MTU:       netConfig.Options["ovn.dhcp4.mtu"],
+			Domain:    netConfig.Options["ovn.dhcp4.domain"],
+		}
+		hash := opts.Hash()
+
+		policyID := fmt.Sprintf("%s:%s", networkID, dhcpv4PolicyGroup)
+		existing, err := d.store.GetPolicy(policyID)
+		dhcpUUID := netConfig.Options["dhcp_uuid"]
+		if err == nil && existing.Hash == hash && dhcpUUID != "" {
+			d.logger.Debugf("DHCPv4 options for network %s unchanged, skipping reprogramming", networkID)
 		} else {
-			d.logger.Infof("Deleted OVN logical port %s", logicalPort)
+			ovnOpts := dhcpv4OptionMap(opts)
+			const serverMAC = "02:00:00:00:00:01"
+			if dhcpUUID != "" {
+				if err := d.ovn.UpdateDHCPOptions(dhcpUUID, serverMAC, netConfig.IPv4Data.Gateway, ovnOpts); err != nil {
+					return fmt.Errorf("DHCPv4 options: %w", err)
+				}
+			} else {
+				dhcpUUID, err = d.ovn.CreateDHCPOptions(netConfig.IPv4Data.Pool, serverMAC, netConfig.IPv4Data.Gateway, ovnOpts)
+				if err != nil {
+					return fmt.Errorf("DHCPv4 options: %w", err)
+				}
+				netConfig.Options["dhcp_uuid"] = dhcpUUID
+				if err := d.store.AddFinalizer(store.FinalizerDHCP, dhcpUUID, networkID); err != nil {
+					d.logger.WithError(err).Warnf("Failed to add finalizer for DHCP options %s", dhcpUUID)
+				}
+			}
+
+			if err := d.store.SavePolicy(&store.PolicyInfo{ID: policyID, NetworkID: networkID, SecurityGroup: dhcpv4PolicyGroup, Hash: hash}); err != nil {
+				d.logger.WithError(err).Warnf("Failed to persist DHCPv4 options hash for network %s", networkID)
+			}
+			d.logger.Infof("Applied DHCPv4 options %s to network %s", dhcpUUID, networkID)
 		}
 	}
 
-	// Clean up OVS port if it exists
-	if ep.PortName != "" {
-		if err := d.ovs.DeletePort(ep.Network.Bridge, ep.PortName); err != nil {
-			d.logger.WithError(err).Warnf("Failed to delete OVS port %s", ep.PortName)
-			// Continue anyway - port might already be gone
+	if netConfig.Options["ovn.dhcp6"] == "true" && netConfig.IPv6Data != nil {
+		serverID := netConfig.Options["ovn.dhcp6.server_id"]
+		if serverID == "" {
+			serverID = netConfig.IPv6Data.Gateway
 		}
-	}
+		opts := ovn.DHCPv6Options{
+			ServerID:  serverID,
+			DNSServer: netConfig.Options["ovn.dhcp6.dns_server"],
+		}
+		hash := opts.Hash()
 
-	delete(d.endpoints, req.EndpointID)
+		policyID := fmt.Sprintf("%s:%s", networkID, dhcpv6PolicyGroup)
+		existing, err := d.store.GetPolicy(policyID)
+		dhcpv6UUID := netConfig.Options["dhcpv6_uuid"]
+		if err == nil && existing.Hash == hash && dhcpv6UUID != "" {
+			d.logger.Debugf("DHCPv6 options for network %s unchanged, skipping reprogramming", networkID)
+		} else {
+			ovnOpts := map[string]string{}
+			if opts.DNSServer != "" {
+				ovnOpts["dns_server"] = opts.DNSServer
+			}
+			if dhcpv6UUID != "" {
+				if err := d.ovn.UpdateDHCPv6Options(dhcpv6UUID, serverID, ovnOpts); err != nil {
+					return fmt.Errorf("DHCPv6 options: %w", err)
+				}
+			} else {
+				dhcpv6UUID, err = d.ovn.CreateDHCPv6Options(netConfig.IPv6Data.Pool, serverID, ovnOpts)
+				if err != nil {
+					return fmt.Errorf("DHCPv6 options: %w", err)
+				}
+				netConfig.Options["dhcpv6_uuid"] = dhcpv6UUID
+				if err := d.store.AddFinalizer(store.FinalizerDHCP, dhcpv6UUID, networkID); err != nil {
+					d.logger.WithError(err).Warnf("Failed to add finalizer for DHCPv6 options %s", dhcpv6UUID)
+				}
+			}
 
-	// Remove from store
-	if err := d.store.DeleteEndpoint(req.NetworkID, req.EndpointID); err != nil {
-		d.logger.WithError(err).Warn("Failed to remove endpoint from store")
-		// Non-fatal: continue even if we can't remove from store
+			if err := d.store.SavePolicy(&store.PolicyInfo{ID: policyID, NetworkID: networkID, SecurityGroup: dhcpv6PolicyGroup, Hash: hash}); err != nil {
+				d.logger.WithError(err).Warnf("Failed to persist DHCPv6 options hash for network %s", networkID)
+			}
+			d.logger.Infof("Applied DHCPv6 options %s to network %s", dhcpv6UUID, networkID)
+		}
 	}
 
-	d.logger.Infof("Endpoint %s deleted", req.EndpointID)
 	return nil
 }
 
-// EndpointInfo returns endpoint information
-func (d *Driver) EndpointInfo(req *dnetwork.InfoRequest) (*dnetwork.InfoResponse, error) {
-	d.RLock()
-	defer d.RUnlock()
+// dhcpv4OptionMap converts opts to the generic key/value map
+// ovn.CreateDHCPOptions/UpdateDHCPOptions expect, omitting anything left
+// unset rather than passing it through as an empty value.
+func dhcpv4OptionMap(opts ovn.DHCPv4Options) map[string]string {
+	m := map[string]string{"lease_time": opts.LeaseTime}
+	if opts.Router != "" {
+		m["router"] = opts.Router
+	}
+	if opts.DNSServer != "" {
+		m["dns_server"] = opts.DNSServer
+	}
+	if opts.MTU != "" {
+		m["mtu"] = opts.MTU
+	}
+	if opts.Domain != "" {
+		m["domain"] = opts.Domain
+	}
+	return m
+}
 
-	d.logger.WithFields(logrus.Fields{
-		"network_id":  req.NetworkID,
-		"endpoint_id": req.EndpointID,
-	}).Debug("EndpointInfo called")
+// addLBBackend adds memberIP as a backend on every VIP of load balancer
+// lbName on networkID, at that VIP's own port, and re-applies the updated
+// backend list to OVN.
+func (d *Driver) addLBBackend(networkID, lbName, memberIP string) error {
+	lbID := fmt.Sprintf("%s:%s", networkID, lbName)
+	lbInfo, err := d.store.GetLB(lbID)
+	if err != nil {
+		return fmt.Errorf("load balancer %s not found on network %s: %w", lbName, networkID, err)
+	}
 
-	ep, exists := d.endpoints[req.EndpointID]
-	if !exists {
-		return nil, fmt.Errorf("endpoint %s not found", req.EndpointID)
+	for vip, backends := range lbInfo.VIPs {
+		_, port, err := net.SplitHostPort(vip)
+		if err != nil {
+			continue
+		}
+		backend := net.JoinHostPort(memberIP, port)
+		if stringSliceContains(backends, backend) {
+			continue
+		}
+		backends = append(backends, backend)
+		lbInfo.VIPs[vip] = backends
+		if err := d.ovn.AddLBVIP(lbInfo.UUID, vip, backends); err != nil {
+			return fmt.Errorf("failed to add backend %s to VIP %s: %w", backend, vip, err)
+		}
 	}
 
-	res := &dnetwork.InfoResponse{
-		Value: make(map[string]string),
+	return d.store.SaveLB(lbInfo)
+}
+
+// removeLBBackend removes memberIP from every VIP of load balancer lbName on
+// networkID and re-applies the updated backend list to OVN, removing the
+// VIP itself if it's left with no backends.
+func (d *Driver) removeLBBackend(networkID, lbName, memberIP string) error {
+	lbID := fmt.Sprintf("%s:%s", networkID, lbName)
+	lbInfo, err := d.store.GetLB(lbID)
+	if err != nil {
+		return fmt.Errorf("load balancer %s not found on network %s: %w", lbName, networkID, err)
 	}
 
-	// Add endpoint information
-	if ep.MacAddress != "" {
-		res.Value["mac_address"] = ep.MacAddress
+	for vip, backends := range lbInfo.VIPs {
+		_, port, err := net.SplitHostPort(vip)
+		if err != nil {
+			continue
+		}
+		backend := net.JoinHostPort(memberIP, port)
+		updated := backends[:0]
+		for _, b := range backends {
+			if b != backend {
+				updated = append(updated, b)
+			}
+		}
+		if len(updated) == len(backends) {
+			continue
+		}
+		lbInfo.VIPs[vip] = updated
+
+		if len(updated) == 0 {
+			if err := d.ovn.RemoveLBVIP(lbInfo.UUID, vip); err != nil {
+				d.logger.WithError(err).Warnf("Failed to remove empty VIP %s from load balancer %s", vip, lbName)
+			}
+		} else if err := d.ovn.AddLBVIP(lbInfo.UUID, vip, updated); err != nil {
+			d.logger.WithError(err).Warnf("Failed to update VIP %s on load balancer %s", vip, lbName)
+		}
 	}
-	if ep.IPv4Address != "" {
-		res.Value["ipv4_address"] = ep.IPv4Address
+
+	return d.store.SaveLB(lbInfo)
+}
+
+// stringSliceContains reports whether s contains v.
+func stringSliceContains(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
 	}
-	if ep.IPv6Address != "" {
-		res.Value["ipv6_address"] = ep.IPv6Address
+	return false
+}
+
+// ListLoadBalancers returns every persisted load balancer, optionally
+// filtered to one network, for the /lb inspection endpoint.
+func (d *Driver) ListLoadBalancers(networkID string) []*store.LBInfo {
+	lbs := d.store.ListLBs()
+	if networkID == "" {
+		return lbs
 	}
-	if ep.PortName != "" {
-		res.Value["ovs_port"] = ep.PortName
+	filtered := make([]*store.LBInfo, 0, len(lbs))
+	for _, lb := range lbs {
+		if lb.NetworkID == networkID {
+			filtered = append(filtered, lb)
+		}
 	}
+	return filtered
+}
 
-	return res, nil
+// AllocateNetwork allocates resources for a network
+func (d *Driver) AllocateNetwork(req *dnetwork.AllocateNetworkRequest) (*dnetwork.AllocateNetworkResponse, error) {
+	d.logger.WithField("network_id", req.NetworkID).Debug("AllocateNetwork called")
+	// No special allocation needed for OVS
+	return &dnetwork.AllocateNetworkResponse{}, nil
 }
 
-// Join joins an endpoint - this is where the actual network connection happens
-func (d *Driver) Join(req *dnetwork.JoinRequest) (*dnetwork.JoinResponse, error) {
+// DeleteNetwork deletes a network
+func (d *Driver) DeleteNetwork(req *dnetwork.DeleteNetworkRequest) error {
 	d.Lock()
 	defer d.Unlock()
 
-	d.logger.WithFields(logrus.Fields{
-		"network_id":  req.NetworkID,
-		"endpoint_id": req.EndpointID,
-		"sandbox_key": req.SandboxKey,
-		"options":     req.Options,
-	}).Info("Join called")
+	d.logger.WithField("network_id", req.NetworkID).Info("DeleteNetwork called")
 
-	ep, exists := d.endpoints[req.EndpointID]
+	net, exists := d.networks[req.NetworkID]
 	if !exists {
-		return nil, fmt.Errorf("endpoint %s not found", req.EndpointID)
+		d.logger.Warnf("Network %s not found", req.NetworkID)
+		return nil // Idempotent
 	}
 
-	// Generate veth pair names
-	vethName := fmt.Sprintf("veth%s", req.EndpointID[:7])
-	vethPeer := fmt.Sprintf("veth%s-p", req.EndpointID[:7])
+	// Check if there are any endpoints still attached
+	for _, ep := range d.endpoints {
+		if ep.NetworkID == req.NetworkID {
+			return fmt.Errorf("network %s still has active endpoints", req.NetworkID)
+		}
+	}
 
-	// Create the veth pair and connect to OVS
-	if err := d.ovs.CreateVethPair(vethName, vethPeer); err != nil {
-		return nil, fmt.Errorf("failed to create veth pair: %w", err)
+	// In a multi-host environment, OVN logical switches and routers may have
+	// containers from other hosts attached via other networks. Only delete
+	// them once this was their last owner, tracked by a store-persisted
+	// finalizer rather than deleting unconditionally.
+	if net.OVNSwitch != "" && d.ovn != nil {
+		d.releaseFinalizedResource(store.FinalizerSwitch, net.OVNSwitch, req.NetworkID, d.ovn.DeleteLogicalSwitch)
 	}
+	if net.OVNRouter != "" && d.ovn != nil {
+		// This network's own router port isn't removed by deleting the
+		// switch - ports live on the router, not the switch - so it must go
+		// explicitly or a router shared by multiple subnets (see
+		// "ovn.router") would accumulate a stale LRP per detached subnet.
+		if err := d.ovn.DeleteLogicalRouterPort(fmt.Sprintf("rp-%s", net.OVNSwitch)); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete router port for switch %s", net.OVNSwitch)
+		}
+		if peerRouter := net.Options["ovn.router.peer"]; peerRouter != "" {
+			if err := d.ovn.DeleteLogicalRouterPort(fmt.Sprintf("rp-%s-%s", net.OVNRouter, peerRouter)); err != nil {
+				d.logger.WithError(err).Warnf("Failed to delete router peer port to %s", peerRouter)
+			}
+			if err := d.ovn.DeleteLogicalRouterPort(fmt.Sprintf("rp-%s-%s", peerRouter, net.OVNRouter)); err != nil {
+				d.logger.WithError(err).Warnf("Failed to delete router peer port from %s", peerRouter)
+			}
+		}
+		if snatOpt := net.Options["ovn.snat"]; snatOpt != "" {
+			if parts := strings.SplitN(snatOpt, "->", 2); len(parts) == 2 {
+				if err := d.ovn.DeleteSNAT(net.OVNRouter, strings.TrimSpace(parts[0])); err != nil {
+					d.logger.WithError(err).Warnf("Failed to delete SNAT rule for network %s", req.NetworkID)
+				}
+			}
+		}
 
-	// Add the peer to the OVS bridge
-	portOptions := make(map[string]string)
+		d.releaseFinalizedResource(store.FinalizerRouter, net.OVNRouter, req.NetworkID, d.ovn.DeleteLogicalRouter)
+	}
+	if dhcpUUID := net.Options["dhcp_uuid"]; dhcpUUID != "" && d.ovn != nil {
+		d.releaseFinalizedResource(store.FinalizerDHCP, dhcpUUID, req.NetworkID, d.ovn.DeleteDHCPOptions)
+	}
+	if dhcpv6UUID := net.Options["dhcpv6_uuid"]; dhcpv6UUID != "" && d.ovn != nil {
+		d.releaseFinalizedResource(store.FinalizerDHCP, dhcpv6UUID, req.NetworkID, d.ovn.DeleteDHCPOptions)
+	}
 
-	// Set external_ids
-	portOptions["external_ids:container_id"] = req.EndpointID
-	portOptions["external_ids:network_id"] = req.NetworkID
+	// Clean up any OVS-specific resources if needed
+	// For now, we keep the bridge as it might be shared
 
-	if ep.Network.TenantID != "" {
-		portOptions["external_ids:tenant_id"] = ep.Network.TenantID
+	// Detach from the distributed router, if this network was attached to
+	// one, decrementing its ref count and GC-ing it once the last switch
+	// has gone.
+	if drName := net.Options["ovn.distributed_router"]; drName != "" && d.ovn != nil {
+		d.detachFromDistributedRouter(drName, net)
 	}
 
-	// If using OVN, set iface-id to bind this port to the logical port
-	if ep.Network.OVNSwitch != "" {
-		// The iface-id must match the OVN logical port name
-		logicalPortName := fmt.Sprintf("lsp-%s", req.EndpointID[:12])
-		portOptions["external_ids:iface-id"] = logicalPortName
-		d.logger.Infof("Setting iface-id for OVN binding: %s", logicalPortName)
+	// Release this VPC router's transit network port allocation once no
+	// other known network still uses it, mirroring the distributed router's
+	// own ref-counted teardown.
+	if net.Options["ovn.transit_network"] != "" && net.OVNRouter != "" {
+		stillUsed := false
+		for id, other := range d.networks {
+			if id != req.NetworkID && other.OVNRouter == net.OVNRouter {
+				stillUsed = true
+				break
+			}
+		}
+		if !stillUsed {
+			if transitNet := d.findTransitNetwork(); transitNet != nil {
+				d.ipam.Release(transitNet.ID, net.OVNRouter)
+				if err := d.store.DeleteTransitPort(transitPortID(transitNet.ID, net.OVNRouter)); err != nil {
+					d.logger.WithError(err).Warn("Failed to remove transit network port allocation from store")
+				}
+			}
+		}
 	}
 
-	// Set VLAN if specified
-	if ep.Network.VLAN != "" {
-		portOptions["tag"] = ep.Network.VLAN
+	// Tear down the u2o localnet port and dedicated router port, and free
+	// the interconnection address, if this network had u2o interconnection
+	// set up in CreateNetwork.
+	if isU2O(net) && net.OVNRouter != "" && d.ovn != nil {
+		d.teardownU2OInterconnection(req.NetworkID, net)
 	}
 
-	// Add port to OVS bridge
-	if err := d.ovs.AddPort(ep.Network.Bridge, vethPeer, portOptions); err != nil {
-		// Clean up veth pair
-		d.ovs.DeleteVethPair(vethName, vethPeer)
-		return nil, fmt.Errorf("failed to add port to OVS: %w", err)
+	// Garbage-collect every load balancer declared on this network via
+	// "ovn.lb.<name>" and its persisted VIP/backend state.
+	if d.ovn != nil {
+		for _, lb := range d.store.ListLBs() {
+			if lb.NetworkID != req.NetworkID {
+				continue
+			}
+			if err := d.ovn.DeleteLoadBalancer(lb.UUID); err != nil {
+				d.logger.WithError(err).Warnf("Failed to delete load balancer %s", lb.Name)
+			}
+			if err := d.store.DeleteLB(lb.ID); err != nil {
+				d.logger.WithError(err).Warnf("Failed to remove persisted load balancer %s", lb.Name)
+			}
+		}
 	}
 
-	// Store the port name for cleanup
-	ep.PortName = vethPeer
-	ep.VethName = vethName
+	// Garbage-collect every security group's Port_Group and its persisted
+	// policy hash, now that no endpoint is left in it.
+	if sgOpt := net.Options["ovn.security_groups"]; sgOpt != "" && d.ovn != nil {
+		for _, sg := range strings.Split(sgOpt, ",") {
+			sg = strings.TrimSpace(sg)
+			if sg == "" {
+				continue
+			}
+			if err := d.ovn.DeletePortGroup(ovn.PortGroupName(req.NetworkID, sg)); err != nil {
+				d.logger.WithError(err).Warnf("Failed to delete port group for security group %s", sg)
+			}
+			if err := d.store.DeletePolicy(fmt.Sprintf("%s:%s", req.NetworkID, sg)); err != nil {
+				d.logger.WithError(err).Warnf("Failed to remove persisted policy for security group %s", sg)
+			}
+		}
+	}
 
-	// Now create OVN logical port with the ACTUAL MAC address of the interface
-	if ep.Network.OVNSwitch != "" && d.ovn != nil {
-		// Get the actual MAC address of the veth interface
-		link, err := netlink.LinkByName(vethName)
-		if err != nil {
-			// Clean up what we created
-			d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
-			d.ovs.DeleteVethPair(vethName, vethPeer)
-			return nil, fmt.Errorf("failed to get veth link info for OVN: %w", err)
+	// Garbage-collect the network-wide microsegmentation Port_Group, any
+	// Address_Sets its "ovn.policy" rules referenced, and the persisted
+	// policy hash, now that no endpoint is left in it.
+	if d.ovn != nil {
+		if err := d.ovn.DeletePortGroup(ovn.NetworkPortGroupName(req.NetworkID)); err != nil {
+			d.logger.WithError(err).Warn("Failed to delete network policy port group")
+		}
+		if rules, err := policy.ParseRules(net.Options["ovn.policy"], net.Options["ovn.policy_file"]); err == nil {
+			for _, r := range rules {
+				if r.Selector == "" {
+					continue
+				}
+				if err := d.ovn.DeleteAddressSet(ovn.AddressSetName(req.NetworkID, r.Selector)); err != nil {
+					d.logger.WithError(err).Warnf("Failed to delete address set for label %s", r.Selector)
+				}
+			}
+		}
+		if err := d.store.DeletePolicy(fmt.Sprintf("%s:%s", req.NetworkID, defaultNetworkPolicyGroup)); err != nil {
+			d.logger.WithError(err).Warn("Failed to remove persisted network policy")
 		}
+	}
 
-		actualMAC := link.Attrs().HardwareAddr.String()
-		d.logger.Infof("Actual veth MAC address: %s", actualMAC)
+	// Garbage-collect the "ovn.ha_chassis_group" named HA_Chassis_Group and
+	// its members, now that the switch port that referenced it is gone.
+	if groupName := net.Options["ovn.ha_chassis_group"]; groupName != "" && d.ovn != nil {
+		if err := d.ovn.DeleteHAChassisGroup(groupName); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete HA chassis group %s", groupName)
+		}
+	}
 
-		// Create logical port name (use endpoint ID for uniqueness)
-		logicalPort := fmt.Sprintf("lsp-%s", req.EndpointID[:12])
+	// For vlan/trunk mode, the parent NIC is a user-supplied physical
+	// interface and is never deleted here; only per-endpoint sub-interfaces
+	// (created in Join) are torn down, in DeleteEndpoint/Leave.
+	if net.Mode == "vlan" || net.Mode == "trunk" {
+		d.logger.Infof("Network %s removed, keeping parent interface %s", req.NetworkID, net.Parent)
+	}
 
-		// Use the actual MAC and the IP address
-		ip := ep.IPv4Address
+	delete(d.networks, req.NetworkID)
 
-		// Create OVN logical port with the real MAC
-		ovnOptions := make(map[string]string)
-		ovnOptions["endpoint_id"] = req.EndpointID
-		ovnOptions["network_id"] = req.NetworkID
-		if ep.Network.TenantID != "" {
-			ovnOptions["tenant_id"] = ep.Network.TenantID
-		}
+	// Remove from store
+	if err := d.store.DeleteNetwork(req.NetworkID); err != nil {
+		d.logger.WithError(err).Warn("Failed to remove network from store")
+		// Non-fatal: continue even if we can't remove from store
+	}
 
-		if err := d.ovn.CreateLogicalPort(ep.Network.OVNSwitch, logicalPort, actualMAC, ip, ovnOptions); err != nil {
-			// This is FATAL - networking will not work without the OVN port
-			// Clean up everything we created
-			d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
-			d.ovs.DeleteVethPair(vethName, vethPeer)
-			return nil, fmt.Errorf("FATAL: failed to create OVN logical port %s: %w", logicalPort, err)
+	d.logger.Infof("Network %s deleted", req.NetworkID)
+	return nil
+}
+
+// FreeNetwork frees network resources
+func (d *Driver) FreeNetwork(req *dnetwork.FreeNetworkRequest) error {
+	d.logger.WithField("network_id", req.NetworkID).Debug("FreeNetwork called")
+	// No special cleanup needed
+	return nil
+}
+
+// persistEndpoint saves the current in-memory state of an endpoint to the
+// store, so a plugin restart has an accurate veth/port name to reconcile
+// against in recoverState.
+func (d *Driver) persistEndpoint(networkID, endpointID string, ep *types.Endpoint) {
+	storeEp := &store.EndpointInfo{
+		ID:          fmt.Sprintf("%s:%s", networkID, endpointID),
+		NetworkID:   networkID,
+		EndpointID:  endpointID,
+		VethName:    ep.VethName,
+		PortName:    ep.PortName,
+		SandboxKey:  ep.SandboxKey,
+		IPAddress:   ep.IPv4Address,
+		IPv6Address: ep.IPv6Address,
+		MACAddress:  ep.MacAddress,
+		OVNPort:     ep.Options["ovn_port"],
+		SNATAddress: ep.SNATAddress,
+		EIPID:       ep.EIPID,
+		Status: store.EndpointStatus{
+			V4Ip:  ep.IPv4Address,
+			V6Ip:  ep.IPv6Address,
+			Ready: ep.Options["ovn_port"] != "",
+		},
+	}
+	for _, pb := range ep.PortBindings {
+		storeEp.PortBindings = append(storeEp.PortBindings, store.PortBinding{
+			Proto:         pb.Proto,
+			ContainerPort: pb.ContainerPort,
+			HostIP:        pb.HostIP,
+			HostPort:      pb.HostPort,
+			HostPortEnd:   pb.HostPortEnd,
+			LBUUID:        pb.LBUUID,
+		})
+	}
+	for _, sec := range ep.SecondaryInterfaces {
+		storeEp.SecondaryInterfaces = append(storeEp.SecondaryInterfaces, store.SecondaryInterface{
+			IfName:      sec.IfName,
+			NetworkID:   sec.NetworkID,
+			MacAddress:  sec.MacAddress,
+			IPv4Address: sec.IPv4Address,
+			VethName:    sec.VethName,
+			PortName:    sec.PortName,
+			OVNPort:     sec.OVNPort,
+		})
+	}
+	if err := d.store.SaveEndpoint(storeEp); err != nil {
+		d.logger.WithError(err).Warn("Failed to persist endpoint to store")
+		// Non-fatal: continue even if we can't persist
+	}
+}
+
+// allocateEIP reserves the next free address in cidr for networkID:endpointID
+// and persists a not-yet-ready status record. Join programs the actual
+// DNAT_and_SNAT rule and gateway LRP once the endpoint's internal address is
+// known; the allocation itself outlives a container restart, so a rejoin
+// reuses the same external address instead of picking a new one.
+func (d *Driver) allocateEIP(networkID, endpointID, cidr string) (*store.EIPInfo, error) {
+	externalIP, err := d.eip.Allocate(networkID, endpointID, cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	eipID := fmt.Sprintf("%s:%s", networkID, endpointID)
+	info := &store.EIPInfo{
+		ID:         eipID,
+		NetworkID:  networkID,
+		EndpointID: endpointID,
+		CIDR:       cidr,
+		V4Ip:       externalIP,
+		MacAddress: hashedMAC(eipID),
+		Ready:      false,
+	}
+	if err := d.store.SaveEIP(info); err != nil {
+		d.logger.WithError(err).Warn("Failed to persist elastic IP allocation")
+	}
+	return info, nil
+}
+
+// eipGatewayPort names the dedicated logical router port an elastic IP's
+// address is attached to, derived from the endpoint ID so Join and the
+// explicit-delete path agree on it without needing to persist it separately.
+func eipGatewayPort(endpointID string) string {
+	return fmt.Sprintf("eip-gw-%s", endpointID[:12])
+}
+
+// CreateEndpoint creates a new endpoint
+func (d *Driver) CreateEndpoint(req *dnetwork.CreateEndpointRequest) (*dnetwork.CreateEndpointResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.logger.WithFields(logrus.Fields{
+		"network_id":  req.NetworkID,
+		"endpoint_id": req.EndpointID,
+		"interface":   req.Interface,
+		"options":     req.Options,
+	}).Info("CreateEndpoint called")
+
+	net, exists := d.networks[req.NetworkID]
+	if !exists {
+		return nil, fmt.Errorf("network %s not found", req.NetworkID)
+	}
+
+	ep := &types.Endpoint{
+		ID:        req.EndpointID,
+		NetworkID: req.NetworkID,
+		Network:   net,
+	}
+
+	// Store MAC address if provided
+	if req.Interface != nil && req.Interface.MacAddress != "" {
+		ep.MacAddress = req.Interface.MacAddress
+	}
+
+	// Store IPv4 address if provided
+	if req.Interface != nil && req.Interface.Address != "" {
+		ep.IPv4Address = req.Interface.Address
+	}
+
+	// Store IPv6 address if provided
+	if req.Interface != nil && req.Interface.AddressIPv6 != "" {
+		ep.IPv6Address = req.Interface.AddressIPv6
+	}
+
+	// Process endpoint options
+	ep.Options = make(map[string]string)
+	for key, value := range req.Options {
+		strValue, ok := value.(string)
+		if !ok {
+			strValue = fmt.Sprintf("%v", value)
 		}
+		ep.Options[key] = strValue
+	}
 
-		// Special handling for NAT gateway - disable port security
-		if ep.Network.Options["ovn.role"] == "transit" && ep.Network.Options["ovn.external_gateway"] != "" {
-			// Check if this is the NAT gateway joining (has the external gateway IP)
-			if ip == ep.Network.Options["ovn.external_gateway"] {
-				d.logger.Infof("NAT gateway detected at %s, disabling port security", ip)
-				if err := d.ovn.DisablePortSecurity(logicalPort); err != nil {
-					// Port security disable failure is critical for NAT gateway
-					d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
-					d.ovs.DeleteVethPair(vethName, vethPeer)
-					d.ovn.DeleteLogicalPort(logicalPort)
-					return nil, fmt.Errorf("FATAL: failed to disable port security for NAT gateway: %w", err)
+	// A caller (e.g. the CNI server, translating per-pod annotations) may
+	// ask this one endpoint to attach to a different logical switch/router
+	// or tenant than the network's own defaults. Join and friends only ever
+	// read from ep.Network, so overriding it here with a shallow copy scopes
+	// the override to this endpoint without touching the shared net config.
+	if ovnSwitch, ovnRouter, tenantID := ep.Options["ovn.switch"], ep.Options["ovn.router"], ep.Options["tenant_id"]; ovnSwitch != "" || ovnRouter != "" || tenantID != "" {
+		netCopy := *net
+		if ovnSwitch != "" {
+			netCopy.OVNSwitch = ovnSwitch
+		}
+		if ovnRouter != "" {
+			netCopy.OVNRouter = ovnRouter
+		}
+		if tenantID != "" {
+			netCopy.TenantID = tenantID
+		}
+		ep.Network = &netCopy
+	}
+
+	// Multi-homed endpoints: "secondary_networks" is a comma-separated list
+	// of additional networks to join alongside the primary, each entry
+	// shaped network_id[:static_ip][:mac] - ovn4nfv's "primary network"
+	// annotation, ported here as a Docker-side endpoint option. Interfaces
+	// are named net1, net2, ... in list order, following the Multus
+	// convention; Join does the actual veth/logical-port work per entry.
+	if secondaryOpt := ep.Options["secondary_networks"]; secondaryOpt != "" {
+		for i, entry := range strings.Split(secondaryOpt, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			// SplitN, not Split: a MAC address contains colons of its
+			// own, so an unbounded split would shred the mac field across
+			// several extra parts instead of leaving it intact as the
+			// third one. An IPv6 static_ip is still split at its own
+			// colons, so only an IPv4 static_ip fits this form.
+			parts := strings.SplitN(entry, ":", 3)
+			secNetID := parts[0]
+			if secNetID == "" {
+				return nil, fmt.Errorf("invalid secondary network entry %q: missing network ID", entry)
+			}
+			if _, ok := d.networks[secNetID]; !ok {
+				return nil, fmt.Errorf("secondary network %s not found", secNetID)
+			}
+			sec := types.SecondaryInterface{
+				IfName:    fmt.Sprintf("net%d", i+1),
+				NetworkID: secNetID,
+			}
+			if len(parts) > 1 && parts[1] != "" {
+				sec.IPv4Address = parts[1]
+			}
+			if len(parts) > 2 && parts[2] != "" {
+				if _, err := net.ParseMAC(parts[2]); err != nil {
+					return nil, fmt.Errorf("invalid secondary network entry %q: invalid MAC %q: %w", entry, parts[2], err)
 				}
+				sec.MacAddress = parts[2]
 			}
+			ep.SecondaryInterfaces = append(ep.SecondaryInterfaces, sec)
 		}
+	}
 
-		// Port binding happens automatically via ovn-controller on the chassis
-		chassis := getChassisID()
-		if chassis != "" {
-			d.logger.Infof("Port %s will be bound by ovn-controller on chassis %s", logicalPort, chassis)
+	// Elastic IP: "ovn.eip=<external-cidr>" allocates a persistent public
+	// address from that pool and binds it to this endpoint's internal
+	// address via an OVN DNAT_and_SNAT rule, Kube-OVN ovn-eip-style. The
+	// rule and its gateway LRP are programmed in Join, once the endpoint's
+	// internal address is known; the allocation survives Leave/DeleteEndpoint
+	// (unlike SNAT-on-host, whose address DeleteEndpoint releases) - only the
+	// /eip admin endpoint frees it.
+	if eipCIDR := ep.Options["ovn.eip"]; eipCIDR != "" {
+		eipInfo, err := d.allocateEIP(req.NetworkID, req.EndpointID, eipCIDR)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate elastic IP: %w", err)
 		}
+		ep.EIPID = eipInfo.ID
+	}
 
-		// Enable DHCP if configured
-		if dhcpUUID := ep.Network.Options["dhcp_uuid"]; dhcpUUID != "" {
-			if err := d.ovn.SetPortDHCP(logicalPort, dhcpUUID); err != nil {
-				// DHCP failure is also critical if it was requested
-				d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
-				d.ovs.DeleteVethPair(vethName, vethPeer)
-				d.ovn.DeleteLogicalPort(logicalPort)
-				return nil, fmt.Errorf("FATAL: failed to set OVN DHCP for port %s: %w", logicalPort, err)
-			}
+	// Allocate an infra-vnet address for this endpoint's SNAT-on-host rule
+	if net.EnableSNATOnHost {
+		snatAddr, err := d.snat.Allocate(req.NetworkID, req.EndpointID, net.InfraVNetCIDR)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate SNAT-on-host address: %w", err)
 		}
-
-		// Store logical port name for cleanup
-		ep.Options["ovn_port"] = logicalPort
-		d.logger.Infof("Created OVN logical port %s with MAC %s", logicalPort, actualMAC)
+		ep.SNATAddress = snatAddr
 	}
 
-	// Set up port mirroring if configured
-	if ep.Network.MirrorPorts != "" && ep.Network.MirrorDest != "" {
-		// Check if this port should be mirrored
-		mirrorPorts := strings.Split(ep.Network.MirrorPorts, ",")
-		for _, mp := range mirrorPorts {
-			mp = strings.TrimSpace(mp)
-			if mp == vethPeer || mp == "all" {
-				// Create a mirror for this port
-				mirrorName := fmt.Sprintf("mirror-%s", req.EndpointID[:7])
-				if err := d.ovs.CreateMirror(ep.Network.Bridge, mirrorName, vethPeer, ep.Network.MirrorDest, nil); err != nil {
-					d.logger.WithError(err).Warnf("Failed to set up port mirror")
-					// Continue anyway - mirroring is not critical
-				} else {
-					d.logger.Infof("Port mirroring enabled for %s -> %s", vethPeer, ep.Network.MirrorDest)
-				}
-				break
-			}
+	// Store the endpoint
+	d.endpoints[req.EndpointID] = ep
+
+	// Persist to store
+	d.persistEndpoint(req.NetworkID, req.EndpointID, ep)
+
+	d.logger.Infof("Endpoint %s created for network %s", req.EndpointID, req.NetworkID)
+
+	resp := &dnetwork.CreateEndpointResponse{}
+
+	// If no MAC was provided, we'll generate one when joining
+	if ep.MacAddress != "" {
+		resp.Interface = &dnetwork.EndpointInterface{
+			MacAddress: ep.MacAddress,
 		}
 	}
 
-	// Build the response
-	resp := &dnetwork.JoinResponse{
-		InterfaceName: dnetwork.InterfaceName{
-			SrcName:   vethName,
-			DstPrefix: "eth",
-		},
+	return resp, nil
+}
+
+// DeleteEndpoint deletes an endpoint
+func (d *Driver) DeleteEndpoint(req *dnetwork.DeleteEndpointRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	d.logger.WithFields(logrus.Fields{
+		"network_id":  req.NetworkID,
+		"endpoint_id": req.EndpointID,
+	}).Info("DeleteEndpoint called")
+
+	ep, exists := d.endpoints[req.EndpointID]
+	if !exists {
+		d.logger.Warnf("Endpoint %s not found", req.EndpointID)
+		return nil // Idempotent
 	}
 
-	// Set gateway if we have IPv4 data
-	if ep.Network.IPv4Data != nil && ep.Network.IPv4Data.Gateway != "" {
-		// Strip CIDR notation if present (Docker expects just the IP)
-		gateway := ep.Network.IPv4Data.Gateway
-		if idx := strings.Index(gateway, "/"); idx != -1 {
-			gateway = gateway[:idx]
+	// Clean up OVN logical port if it exists
+	if ep.Options != nil && ep.Options["ovn_port"] != "" && d.ovn != nil {
+		logicalPort := ep.Options["ovn_port"]
+		if err := d.ovn.DeleteLogicalPort(logicalPort); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete OVN logical port %s", logicalPort)
+		} else {
+			d.logger.Infof("Deleted OVN logical port %s", logicalPort)
+		}
+		// Usually already released in Leave; RemoveFinalizer is idempotent
+		// so this is a harmless no-op if so, and a safety net if Leave
+		// never ran (e.g. the sandbox was torn down without it).
+		if ep.Network.OVNSwitch != "" {
+			d.releaseFinalizedResource(store.FinalizerSwitch, ep.Network.OVNSwitch, req.EndpointID, d.ovn.DeleteLogicalSwitch)
 		}
-		resp.Gateway = gateway
 	}
 
-	// Set IPv6 gateway if we have IPv6 data
-	if ep.Network.IPv6Data != nil && ep.Network.IPv6Data.Gateway != "" {
-		// Strip CIDR notation if present
-		gateway := ep.Network.IPv6Data.Gateway
-		if idx := strings.Index(gateway, "/"); idx != -1 {
-			gateway = gateway[:idx]
+	// Clean up OVS port if it exists
+	if ep.PortName != "" {
+		if err := d.ovs.DeletePort(ep.Network.Bridge, ep.PortName); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete OVS port %s", ep.PortName)
+			// Continue anyway - port might already be gone
 		}
-		resp.GatewayIPv6 = gateway
 	}
 
-	// Disable Docker's gateway service if we're using external DHCP
-	if ep.Network.Options["ipam"] == "external" || ep.Network.Options["dhcp"] == "true" {
-		resp.DisableGatewayService = true
+	// Usually already torn down in RevokeExternalConnectivity;
+	// releasePortBindingLBs is idempotent, so this is a harmless no-op if
+	// so, and a safety net if Revoke never ran.
+	if len(ep.PortBindings) > 0 {
+		d.releasePortBindingLBs(ep.PortBindings)
+		d.portmap.Release(req.NetworkID, req.EndpointID)
 	}
 
-	d.logger.Infof("Container joined network %s via %s", req.NetworkID, vethName)
-	return resp, nil
+	// Usually already torn down in Leave; leaveSecondaryInterface is
+	// idempotent, so this is a harmless no-op if so, and a safety net for
+	// any secondary interface if Leave never ran.
+	for i := range ep.SecondaryInterfaces {
+		d.leaveSecondaryInterface(req.EndpointID, &ep.SecondaryInterfaces[i])
+	}
+
+	// Tear down the SNAT-on-host rule and free the infra-vnet address
+	if ep.SNATAddress != "" {
+		if err := d.snat.RemoveRule(ep.IPv4Address, ep.SNATAddress); err != nil {
+			d.logger.WithError(err).Warnf("Failed to remove SNAT rule for endpoint %s", req.EndpointID)
+		}
+		d.snat.Release(req.NetworkID, req.EndpointID)
+	}
+
+	// Usually already torn down in Leave; unprogramEIP is idempotent, so this
+	// is a harmless no-op if so, and a safety net if Leave never ran. The
+	// elastic IP allocation itself is kept - only an explicit /eip delete frees it.
+	if ep.EIPID != "" {
+		d.unprogramEIP(ep)
+	}
+
+	// Same safety net for a distributed-gateway-mode reroute policy.
+	d.removeDistributedGatewayPolicy(ep)
+
+	delete(d.endpoints, req.EndpointID)
+
+	// Remove from store
+	if err := d.store.DeleteEndpoint(req.NetworkID, req.EndpointID); err != nil {
+		d.logger.WithError(err).Warn("Failed to remove endpoint from store")
+		// Non-fatal: continue even if we can't remove from store
+	}
+
+	d.logger.Infof("Endpoint %s deleted", req.EndpointID)
+	return nil
 }
 
-// Leave leaves an endpoint
-func (d *Driver) Leave(req *dnetwork.LeaveRequest) error {
-	d.Lock()
-	defer d.Unlock()
+// EndpointInfo returns endpoint information
+func (d *Driver) EndpointInfo(req *dnetwork.InfoRequest) (*dnetwork.InfoResponse, error) {
+	d.RLock()
+	defer d.RUnlock()
 
 	d.logger.WithFields(logrus.Fields{
 		"network_id":  req.NetworkID,
 		"endpoint_id": req.EndpointID,
-	}).Info("Leave called")
+	}).Debug("EndpointInfo called")
+
+	ep, exists := d.endpoints[req.EndpointID]
+	if !exists {
+		return nil, fmt.Errorf("endpoint %s not found", req.EndpointID)
+	}
+
+	res := &dnetwork.InfoResponse{
+		Value: make(map[string]string),
+	}
+
+	// Add endpoint information
+	if ep.MacAddress != "" {
+		res.Value["mac_address"] = ep.MacAddress
+	}
+	if ep.IPv4Address != "" {
+		res.Value["ipv4_address"] = ep.IPv4Address
+	}
+	if ep.IPv6Address != "" {
+		res.Value["ipv6_address"] = ep.IPv6Address
+	}
+	if ep.PortName != "" {
+		res.Value["ovs_port"] = ep.PortName
+	}
+	if chassis := ep.Options["ovn_chassis"]; chassis != "" {
+		res.Value["ovn_chassis"] = chassis
+	}
+	if boundAt := ep.Options["ovn_bound_at"]; boundAt != "" {
+		res.Value["ovn_bound_at"] = boundAt
+	}
+
+	return res, nil
+}
+
+// defaultChassisTimeout bounds how long Join waits for this host's chassis
+// to register in the OVN Southbound database before giving up.
+const defaultChassisTimeout = 5 * time.Second
+
+// defaultBindTimeout is the fallback for ovn.bind_timeout, bounding how
+// long Join waits for ovn-controller to bind a newly created logical port
+// to the local chassis.
+const defaultBindTimeout = 10 * time.Second
+
+// Join joins an endpoint - this is where the actual network connection happens
+func (d *Driver) Join(req *dnetwork.JoinRequest) (*dnetwork.JoinResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.logger.WithFields(logrus.Fields{
+		"network_id":  req.NetworkID,
+		"endpoint_id": req.EndpointID,
+		"sandbox_key": req.SandboxKey,
+		"options":     req.Options,
+	}).Info("Join called")
+
+	ep, exists := d.endpoints[req.EndpointID]
+	if !exists {
+		return nil, fmt.Errorf("endpoint %s not found", req.EndpointID)
+	}
+	ep.SandboxKey = req.SandboxKey
+
+	if ep.Network.Mode == "vlan" || ep.Network.Mode == "trunk" {
+		return d.joinVlan(req, ep)
+	}
+
+	// Chassis readiness gate: refuse to serve Join until ovn-controller has
+	// registered this host's chassis in the Southbound database, mirroring
+	// the subnet-ready poll pattern kube-ovn controllers use - otherwise the
+	// port we're about to create can never bind and the container starts
+	// with a dead interface.
+	if ep.Network.OVNSwitch != "" && d.ovn != nil && !isU2O(ep.Network) {
+		chassisID := getChassisID()
+		if chassisID == "" {
+			return nil, fmt.Errorf("cannot determine local OVN chassis id")
+		}
+		d.checkChassisIDChange(chassisID)
+		if err := d.ovn.WaitForChassis(chassisID, defaultChassisTimeout); err != nil {
+			return nil, fmt.Errorf("chassis not ready: %w", err)
+		}
+	}
+
+	// Generate veth pair names
+	vethName := fmt.Sprintf("veth%s", req.EndpointID[:7])
+	vethPeer := fmt.Sprintf("veth%s-p", req.EndpointID[:7])
+
+	// Create the veth pair and connect to OVS
+	if err := d.ovs.CreateVethPair(vethName, vethPeer); err != nil {
+		return nil, fmt.Errorf("failed to create veth pair: %w", err)
+	}
+
+	// Add the peer to the OVS bridge
+	portOptions := make(map[string]string)
+
+	// Set external_ids
+	portOptions["external_ids:container_id"] = req.EndpointID
+	portOptions["external_ids:network_id"] = req.NetworkID
+
+	if ep.Network.TenantID != "" {
+		portOptions["external_ids:tenant_id"] = ep.Network.TenantID
+	}
+
+	// If using OVN, set iface-id to bind this port to the logical port
+	if ep.Network.OVNSwitch != "" {
+		// The iface-id must match the OVN logical port name
+		logicalPortName := fmt.Sprintf("lsp-%s", req.EndpointID[:12])
+		portOptions["external_ids:iface-id"] = logicalPortName
+		d.logger.Infof("Setting iface-id for OVN binding: %s", logicalPortName)
+	}
+
+	// Set VLAN if specified
+	if ep.Network.VLAN != "" {
+		portOptions["tag"] = ep.Network.VLAN
+	} else if isU2O(ep.Network) {
+		// u2o traffic egresses via the network's shared localnet port rather
+		// than a per-endpoint OVN logical port, so the endpoint's own OVS
+		// port just needs tagging onto the underlay VLAN.
+		if u2oVLAN := ep.Network.Options["ovn.u2o_vlan"]; u2oVLAN != "" {
+			portOptions["tag"] = u2oVLAN
+		}
+	}
+
+	// Add port to OVS bridge
+	if err := d.ovs.AddPort(ep.Network.Bridge, vethPeer, portOptions); err != nil {
+		// Clean up veth pair
+		d.ovs.DeleteVethPair(vethName, vethPeer)
+		return nil, fmt.Errorf("failed to add port to OVS: %w", err)
+	}
+
+	// Store the port name for cleanup
+	ep.PortName = vethPeer
+	ep.VethName = vethName
+
+	// Now create OVN logical port with the ACTUAL MAC address of the interface.
+	// u2o networks skip this entirely - traffic egresses via the shared
+	// localnet port setupU2OInterconnection created, not a per-endpoint port.
+	if ep.Network.OVNSwitch != "" && d.ovn != nil && !isU2O(ep.Network) {
+		// Get the actual MAC address of the veth interface
+		actualMAC, err := d.ovs.GetLinkMAC(vethName)
+		if err != nil {
+			// Clean up what we created
+			d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
+			d.ovs.DeleteVethPair(vethName, vethPeer)
+			return nil, fmt.Errorf("failed to get veth link info for OVN: %w", err)
+		}
+		d.logger.Infof("Actual veth MAC address: %s", actualMAC)
+
+		// Create logical port name (use endpoint ID for uniqueness)
+		logicalPort := fmt.Sprintf("lsp-%s", req.EndpointID[:12])
+
+		// Use the actual MAC and the IP address(es) - lsp-set-addresses accepts
+		// multiple addresses space-separated for dual-stack ports
+		ip := ep.IPv4Address
+		if ep.IPv6Address != "" {
+			ip = strings.TrimSpace(ip + " " + ep.IPv6Address)
+		}
+
+		// Create OVN logical port with the real MAC
+		ovnOptions := make(map[string]string)
+		ovnOptions["endpoint_id"] = req.EndpointID
+		ovnOptions["network_id"] = req.NetworkID
+		if ep.Network.TenantID != "" {
+			ovnOptions["tenant_id"] = ep.Network.TenantID
+		}
+
+		if err := d.ovn.CreateLogicalPort(ep.Network.OVNSwitch, logicalPort, actualMAC, ip, ovnOptions); err != nil {
+			// This is FATAL - networking will not work without the OVN port
+			// Clean up everything we created
+			d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
+			d.ovs.DeleteVethPair(vethName, vethPeer)
+			return nil, fmt.Errorf("FATAL: failed to create OVN logical port %s: %w", logicalPort, err)
+		}
+		if err := d.store.AddFinalizer(store.FinalizerSwitch, ep.Network.OVNSwitch, req.EndpointID); err != nil {
+			d.logger.WithError(err).Warnf("Failed to add finalizer for switch %s", ep.Network.OVNSwitch)
+		}
+
+		// Special handling for NAT gateway - disable port security
+		if ep.Network.Options["ovn.role"] == "transit" && ep.Network.Options["ovn.external_gateway"] != "" {
+			// Check if this is the NAT gateway joining (has the external gateway IP)
+			if ip == ep.Network.Options["ovn.external_gateway"] {
+				d.logger.Infof("NAT gateway detected at %s, disabling port security", ip)
+				if err := d.ovn.DisablePortSecurity(logicalPort); err != nil {
+					// Port security disable failure is critical for NAT gateway
+					d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
+					d.ovs.DeleteVethPair(vethName, vethPeer)
+					d.ovn.DeleteLogicalPort(logicalPort)
+					return nil, fmt.Errorf("FATAL: failed to disable port security for NAT gateway: %w", err)
+				}
+			}
+		}
+
+		// Port binding happens automatically via ovn-controller on the
+		// chassis; confirm it actually happened instead of just hoping, so a
+		// container never starts with a dead interface because
+		// ovn-controller never bound the port.
+		chassis := getChassisID()
+		if chassis != "" {
+			d.logger.Infof("Port %s will be bound by ovn-controller on chassis %s", logicalPort, chassis)
+		}
+
+		// Enable DHCP if configured
+		if dhcpUUID := ep.Network.Options["dhcp_uuid"]; dhcpUUID != "" {
+			if err := d.ovn.SetPortDHCP(logicalPort, dhcpUUID); err != nil {
+				// DHCP failure is also critical if it was requested
+				d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
+				d.ovs.DeleteVethPair(vethName, vethPeer)
+				d.ovn.DeleteLogicalPort(logicalPort)
+				return nil, fmt.Errorf("FATAL: failed to set OVN DHCP for port %s: %w", logicalPort, err)
+			}
+		}
+
+		// Enable DHCPv6 if configured
+		if dhcpv6UUID := ep.Network.Options["dhcpv6_uuid"]; dhcpv6UUID != "" {
+			if err := d.ovn.SetPortDHCPv6(logicalPort, dhcpv6UUID); err != nil {
+				// DHCPv6 failure is also critical if it was requested
+				d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
+				d.ovs.DeleteVethPair(vethName, vethPeer)
+				d.ovn.DeleteLogicalPort(logicalPort)
+				return nil, fmt.Errorf("FATAL: failed to set OVN DHCPv6 for port %s: %w", logicalPort, err)
+			}
+		}
+
+		// Confirm the port actually bound to this chassis before handing the
+		// sandbox back to Docker - on timeout, roll back everything we
+		// created and return an error so Docker retries instead of starting
+		// the container with a dead interface.
+		if chassis != "" {
+			bindTimeout := defaultBindTimeout
+			if t := ep.Network.Options["ovn.bind_timeout"]; t != "" {
+				if parsed, err := time.ParseDuration(t); err == nil {
+					bindTimeout = parsed
+				} else {
+					d.logger.Warnf("Invalid ovn.bind_timeout %q, using default %s", t, defaultBindTimeout)
+				}
+			}
+
+			chassisUUID, err := d.ovn.ChassisUUID(chassis)
+			if err != nil || chassisUUID == "" {
+				d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
+				d.ovs.DeleteVethPair(vethName, vethPeer)
+				d.ovn.DeleteLogicalPort(logicalPort)
+				return nil, fmt.Errorf("FATAL: local chassis %s not registered in OVN Southbound: %w", chassis, err)
+			}
+
+			if err := d.ovn.WaitForPortBinding(logicalPort, chassisUUID, bindTimeout); err != nil {
+				d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
+				d.ovs.DeleteVethPair(vethName, vethPeer)
+				d.ovn.DeleteLogicalPort(logicalPort)
+				return nil, fmt.Errorf("FATAL: port %s did not bind to chassis %s: %w", logicalPort, chassis, err)
+			}
+
+			ep.Options["ovn_chassis"] = chassis
+			ep.Options["ovn_bound_at"] = time.Now().Format(time.RFC3339)
+			d.logger.Infof("Port %s bound to chassis %s", logicalPort, chassis)
+		}
+
+		// Store logical port name for cleanup
+		ep.Options["ovn_port"] = logicalPort
+		d.logger.Infof("Created OVN logical port %s with MAC %s", logicalPort, actualMAC)
+
+		// Add the port to each security group's Port_Group, so the ACLs
+		// CreateNetwork installed on it start matching this endpoint's traffic.
+		if sgOpt := ep.Options["security_groups"]; sgOpt != "" {
+			for _, sg := range strings.Split(sgOpt, ",") {
+				sg = strings.TrimSpace(sg)
+				if sg == "" {
+					continue
+				}
+				pgName := ovn.PortGroupName(ep.NetworkID, sg)
+				if err := d.ovn.AddPortToPortGroup(pgName, logicalPort); err != nil {
+					d.logger.WithError(err).Warnf("Failed to add port %s to security group %s", logicalPort, sg)
+				}
+			}
+		}
+
+		// Add this endpoint's address as a backend on every load balancer
+		// named in "lb_member", so a container joining a network labelled
+		// as a backend starts receiving traffic for the VIP automatically.
+		if lbOpt := ep.Options["lb_member"]; lbOpt != "" && ep.IPv4Address != "" {
+			for _, lbName := range strings.Split(lbOpt, ",") {
+				lbName = strings.TrimSpace(lbName)
+				if lbName == "" {
+					continue
+				}
+				if err := d.addLBBackend(ep.NetworkID, lbName, stripCIDR(ep.IPv4Address)); err != nil {
+					d.logger.WithError(err).Warnf("Failed to add endpoint %s as a backend on load balancer %s", req.EndpointID, lbName)
+				}
+			}
+		}
+
+		// Unconditionally add this endpoint's port to its network's
+		// microsegmentation Port_Group, so any "ovn.policy" rules declared on
+		// the network start matching its traffic - unlike security_groups,
+		// this membership isn't opt-in.
+		if err := d.ovn.AddPortToPortGroup(ovn.NetworkPortGroupName(ep.NetworkID), logicalPort); err != nil {
+			d.logger.WithError(err).Warnf("Failed to add port %s to network policy group", logicalPort)
+		}
+
+		// Add this endpoint's address to every label's Address_Set named in
+		// "policy_labels", so policy rules that select that label start
+		// matching it.
+		if labelOpt := ep.Options["policy_labels"]; labelOpt != "" && ep.IPv4Address != "" {
+			for _, label := range strings.Split(labelOpt, ",") {
+				label = strings.TrimSpace(label)
+				if label == "" {
+					continue
+				}
+				asName := ovn.AddressSetName(ep.NetworkID, label)
+				if err := d.ovn.CreateAddressSet(asName); err != nil {
+					d.logger.WithError(err).Warnf("Failed to create address set for label %s", label)
+					continue
+				}
+				if err := d.ovn.AddAddressToSet(asName, stripCIDR(ep.IPv4Address)); err != nil {
+					d.logger.WithError(err).Warnf("Failed to add endpoint %s to address set for label %s", req.EndpointID, label)
+				}
+			}
+		}
+	}
+
+	// Program the host SNAT rule for this endpoint's egress, if enabled
+	if ep.Network.EnableSNATOnHost && ep.SNATAddress != "" && ep.IPv4Address != "" {
+		if err := d.snat.ProgramRule(ep.IPv4Address, ep.SNATAddress); err != nil {
+			// Clean up everything we created
+			if ep.Options["ovn_port"] != "" && d.ovn != nil {
+				d.ovn.DeleteLogicalPort(ep.Options["ovn_port"])
+			}
+			d.ovs.DeletePort(ep.Network.Bridge, vethPeer)
+			d.ovs.DeleteVethPair(vethName, vethPeer)
+			return nil, fmt.Errorf("failed to program SNAT-on-host rule: %w", err)
+		}
+		d.logger.Infof("Programmed SNAT-on-host rule for endpoint %s: %s -> %s", req.EndpointID, ep.IPv4Address, ep.SNATAddress)
+	}
+
+	// Program this endpoint's elastic IP, if "ovn.eip" was requested at
+	// CreateEndpoint. Not fatal to Join: the allocation already survived, and
+	// a failed rule is visible in the persisted status record's conditions.
+	if ep.EIPID != "" {
+		if err := d.programEIP(ep); err != nil {
+			d.logger.WithError(err).Warnf("Failed to program elastic IP for endpoint %s", req.EndpointID)
+		}
+	}
+
+	// In distributed gateway mode, reroute this endpoint's egress to its
+	// local chassis's dedicated gateway port instead of the shared one.
+	// Not fatal to Join: traffic still egresses via the router's other routes.
+	if err := d.applyDistributedGatewayPolicy(ep); err != nil {
+		d.logger.WithError(err).Warnf("Failed to apply distributed gateway policy for endpoint %s", req.EndpointID)
+	}
+
+	// Set up port mirroring if configured
+	if ep.Network.MirrorPorts != "" && ep.Network.MirrorDest != "" {
+		// Check if this port should be mirrored
+		mirrorPorts := strings.Split(ep.Network.MirrorPorts, ",")
+		for _, mp := range mirrorPorts {
+			mp = strings.TrimSpace(mp)
+			if mp == vethPeer || mp == "all" {
+				// Create a mirror for this port
+				mirrorName := fmt.Sprintf("mirror-%s", req.EndpointID[:7])
+				if err := d.ovs.CreateMirror(ep.Network.Bridge, mirrorName, vethPeer, ep.Network.MirrorDest, nil); err != nil {
+					d.logger.WithError(err).Warnf("Failed to set up port mirror")
+					// Continue anyway - mirroring is not critical
+				} else {
+					d.logger.Infof("Port mirroring enabled for %s -> %s", vethPeer, ep.Network.MirrorDest)
+				}
+				break
+			}
+		}
+	}
+
+	// Join any secondary networks requested via the "secondary_networks"
+	// option, each getting its own veth pair and (if OVN-backed) logical
+	// port, attached straight into the sandbox since Docker only moves the
+	// primary interface itself.
+	if len(ep.SecondaryInterfaces) > 0 {
+		if err := d.joinSecondaryInterfaces(req, ep); err != nil {
+			return nil, err
+		}
+	}
+
+	// Persist the veth/port names and any OVN port we just created, so a
+	// plugin restart can reconcile against them
+	d.persistEndpoint(req.NetworkID, req.EndpointID, ep)
+	d.sandboxes[req.SandboxKey] = ep
+
+	// Build the response
+	resp := &dnetwork.JoinResponse{
+		InterfaceName: dnetwork.InterfaceName{
+			SrcName:   vethName,
+			DstPrefix: "eth",
+		},
+	}
+
+	// Set gateway if we have IPv4 data
+	if ep.Network.IPv4Data != nil && ep.Network.IPv4Data.Gateway != "" {
+		// Strip CIDR notation if present (Docker expects just the IP)
+		gateway := ep.Network.IPv4Data.Gateway
+		if idx := strings.Index(gateway, "/"); idx != -1 {
+			gateway = gateway[:idx]
+		}
+		resp.Gateway = gateway
+	}
+
+	// Set IPv6 gateway if we have IPv6 data
+	if ep.Network.IPv6Data != nil && ep.Network.IPv6Data.Gateway != "" {
+		// Strip CIDR notation if present
+		gateway := ep.Network.IPv6Data.Gateway
+		if idx := strings.Index(gateway, "/"); idx != -1 {
+			gateway = gateway[:idx]
+		}
+		resp.GatewayIPv6 = gateway
+	}
+
+	// Disable Docker's gateway service if we're using external DHCP,
+	// including OVN's own native responder (see reconcileDHCPOptions).
+	if ep.Network.Options["ipam"] == "external" || ep.Network.Options["dhcp"] == "true" ||
+		ep.Network.Options["ovn.dhcp4"] == "true" || ep.Network.Options["ovn.dhcp6"] == "true" {
+		resp.DisableGatewayService = true
+	}
+
+	d.logger.Infof("Container joined network %s via %s", req.NetworkID, vethName)
+	return resp, nil
+}
+
+// joinSecondaryInterfaces creates a veth pair and, if the target network is
+// OVN-backed, a logical port for each of ep's secondary networks, then
+// moves it straight into the sandbox named as its IfName (net1, net2, ...).
+// Docker's libnetwork only ever moves the primary interface itself, using
+// the InterfaceName returned from Join, so these are attached directly via
+// the same mechanism the CNI server uses for its primary interface (see
+// ovs.Client.ConfigureEndpointInNamespace).
+func (d *Driver) joinSecondaryInterfaces(req *dnetwork.JoinRequest, ep *types.Endpoint) error {
+	for i := range ep.SecondaryInterfaces {
+		sec := &ep.SecondaryInterfaces[i]
+
+		secNet, exists := d.networks[sec.NetworkID]
+		if !exists {
+			return fmt.Errorf("secondary network %s not found", sec.NetworkID)
+		}
+
+		vethName := fmt.Sprintf("veth%s-%s", req.EndpointID[:7], sec.IfName)
+		vethPeer := fmt.Sprintf("veth%s-%s-p", req.EndpointID[:7], sec.IfName)
+
+		if err := d.ovs.CreateVethPair(vethName, vethPeer); err != nil {
+			return fmt.Errorf("failed to create veth pair for %s: %w", sec.IfName, err)
+		}
+
+		logicalPort := fmt.Sprintf("lsp-%s-%s", req.EndpointID[:12], sec.IfName)
+		portOptions := map[string]string{
+			"external_ids:container_id": req.EndpointID,
+			"external_ids:network_id":   sec.NetworkID,
+		}
+		if secNet.OVNSwitch != "" {
+			portOptions["external_ids:iface-id"] = logicalPort
+		}
+
+		if err := d.ovs.AddPort(secNet.Bridge, vethPeer, portOptions); err != nil {
+			d.ovs.DeleteVethPair(vethName, vethPeer)
+			return fmt.Errorf("failed to add OVS port for %s: %w", sec.IfName, err)
+		}
+		sec.VethName = vethName
+		sec.PortName = vethPeer
+
+		if secNet.OVNSwitch != "" && d.ovn != nil {
+			actualMAC, err := d.ovs.GetLinkMAC(vethName)
+			if err != nil {
+				d.ovs.DeletePort(secNet.Bridge, vethPeer)
+				d.ovs.DeleteVethPair(vethName, vethPeer)
+				return fmt.Errorf("failed to get veth link info for %s: %w", sec.IfName, err)
+			}
+			if sec.MacAddress == "" {
+				sec.MacAddress = actualMAC
+			}
+
+			if err := d.ovn.CreateLogicalPort(secNet.OVNSwitch, logicalPort, actualMAC, sec.IPv4Address, map[string]string{
+				"endpoint_id": req.EndpointID,
+				"network_id":  sec.NetworkID,
+			}); err != nil {
+				d.ovs.DeletePort(secNet.Bridge, vethPeer)
+				d.ovs.DeleteVethPair(vethName, vethPeer)
+				return fmt.Errorf("FATAL: failed to create OVN logical port for %s: %w", sec.IfName, err)
+			}
+			sec.OVNPort = logicalPort
+			if err := d.store.AddFinalizer(store.FinalizerSwitch, secNet.OVNSwitch, req.EndpointID); err != nil {
+				d.logger.WithError(err).Warnf("Failed to add finalizer for switch %s", secNet.OVNSwitch)
+			}
+		}
+
+		if err := d.ovs.ConfigureEndpointInNamespace(vethName, req.SandboxKey, sec.IfName, sec.IPv4Address, ""); err != nil {
+			return fmt.Errorf("failed to attach %s to sandbox: %w", sec.IfName, err)
+		}
+
+		d.logger.Infof("Joined secondary network %s as %s for endpoint %s", sec.NetworkID, sec.IfName, req.EndpointID)
+	}
+	return nil
+}
+
+// leaveSecondaryInterface tears down one secondary interface's OVN logical
+// port, OVS port, and veth pair, clearing sec in place so the next persist
+// doesn't resurrect it. Idempotent: called from both Leave (the normal
+// teardown path) and, as a safety net, DeleteEndpoint.
+func (d *Driver) leaveSecondaryInterface(endpointID string, sec *types.SecondaryInterface) {
+	secNet, secNetExists := d.networks[sec.NetworkID]
+
+	if sec.OVNPort != "" && d.ovn != nil {
+		if err := d.ovn.DeleteLogicalPort(sec.OVNPort); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete OVN logical port %s", sec.OVNPort)
+		}
+		if secNetExists && secNet.OVNSwitch != "" {
+			d.releaseFinalizedResource(store.FinalizerSwitch, secNet.OVNSwitch, endpointID, d.ovn.DeleteLogicalSwitch)
+		}
+	}
+
+	if sec.PortName != "" {
+		bridge := ""
+		if secNetExists {
+			bridge = secNet.Bridge
+		}
+		if err := d.ovs.DeletePort(bridge, sec.PortName); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete OVS port %s", sec.PortName)
+		}
+	}
+
+	if sec.VethName != "" {
+		if err := d.ovs.DeleteVethPair(sec.VethName, sec.PortName); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete veth pair %s", sec.VethName)
+		}
+	}
+
+	sec.VethName = ""
+	sec.PortName = ""
+	sec.OVNPort = ""
+}
+
+// programEIP binds ep's elastic IP to its now-known internal address: a
+// DNAT_and_SNAT rule on the network's logical router for the external <->
+// internal translation, plus a dedicated logical router port so the external
+// address has an LRP on the gateway chassis. Idempotent, like the OVN calls
+// it wraps, so a rejoin that finds the rule already in place is a no-op.
+func (d *Driver) programEIP(ep *types.Endpoint) error {
+	if ep.Network.OVNRouter == "" || d.ovn == nil {
+		return fmt.Errorf("elastic IP requested but network %s has no OVN router", ep.NetworkID)
+	}
+	if ep.IPv4Address == "" {
+		return fmt.Errorf("elastic IP requested but endpoint %s has no internal address", ep.ID)
+	}
+
+	eipInfo, err := d.store.GetEIP(ep.EIPID)
+	if err != nil {
+		return fmt.Errorf("failed to look up elastic IP %s: %w", ep.EIPID, err)
+	}
+
+	internalIP := ep.IPv4Address
+	if idx := strings.Index(internalIP, "/"); idx != -1 {
+		internalIP = internalIP[:idx]
+	}
+
+	if err := d.ovn.AddDNATAndSNAT(ep.Network.OVNRouter, eipInfo.V4Ip, internalIP); err != nil {
+		return fmt.Errorf("failed to program DNAT_and_SNAT for elastic IP %s: %w", eipInfo.V4Ip, err)
+	}
+
+	gwPort := eipGatewayPort(ep.ID)
+	if err := d.ovn.CreateLogicalRouterPort(ep.Network.OVNRouter, gwPort, eipInfo.MacAddress, []string{eipInfo.V4Ip + "/32"}); err != nil {
+		d.ovn.DeleteDNATAndSNAT(ep.Network.OVNRouter, eipInfo.V4Ip)
+		return fmt.Errorf("failed to create gateway LRP for elastic IP %s: %w", eipInfo.V4Ip, err)
+	}
+
+	eipInfo.Ready = true
+	eipInfo.Conditions = nil
+	if err := d.store.SaveEIP(eipInfo); err != nil {
+		d.logger.WithError(err).Warn("Failed to persist elastic IP status")
+	}
+
+	d.logger.Infof("Elastic IP %s bound to endpoint %s (%s)", eipInfo.V4Ip, ep.ID, internalIP)
+	return nil
+}
+
+// unprogramEIP removes the DNAT_and_SNAT rule and gateway LRP for ep's
+// elastic IP and flips its status to not-ready, without releasing the
+// underlying allocation - Leave and DeleteEndpoint's safety net both call
+// this so a rejoin gets the same external address back.
+func (d *Driver) unprogramEIP(ep *types.Endpoint) {
+	if ep.EIPID == "" {
+		return
+	}
+
+	eipInfo, err := d.store.GetEIP(ep.EIPID)
+	if err != nil {
+		d.logger.WithError(err).Warnf("Failed to look up elastic IP %s to tear down", ep.EIPID)
+		return
+	}
+
+	if ep.Network.OVNRouter != "" && d.ovn != nil {
+		if err := d.ovn.DeleteDNATAndSNAT(ep.Network.OVNRouter, eipInfo.V4Ip); err != nil {
+			d.logger.WithError(err).Warnf("Failed to remove DNAT_and_SNAT for elastic IP %s", eipInfo.V4Ip)
+		}
+		if err := d.ovn.DeleteLogicalRouterPort(eipGatewayPort(ep.ID)); err != nil {
+			d.logger.WithError(err).Warnf("Failed to remove gateway LRP for elastic IP %s", eipInfo.V4Ip)
+		}
+	}
+
+	eipInfo.Ready = false
+	if err := d.store.SaveEIP(eipInfo); err != nil {
+		d.logger.WithError(err).Warn("Failed to persist elastic IP status")
+	}
+}
+
+// joinVlan handles Join for "vlan"/"trunk" mode networks: the container is
+// given a tagged 802.1Q sub-interface of the network's parent NIC directly,
+// with no OVS bridge or OVN logical port involved.
+func (d *Driver) joinVlan(req *dnetwork.JoinRequest, ep *types.Endpoint) (*dnetwork.JoinResponse, error) {
+	vlanID := 0
+	fmt.Sscanf(ep.Network.VLAN, "%d", &vlanID)
+
+	subName := fmt.Sprintf("veth%s", req.EndpointID[:7])
+	if err := vlan.CreateVlanSubinterface(ep.Network.Parent, subName, vlanID); err != nil {
+		return nil, fmt.Errorf("failed to create VLAN sub-interface: %w", err)
+	}
+
+	ep.VethName = subName
+	d.persistEndpoint(req.NetworkID, req.EndpointID, ep)
+	d.sandboxes[req.SandboxKey] = ep
+
+	resp := &dnetwork.JoinResponse{
+		InterfaceName: dnetwork.InterfaceName{
+			SrcName:   subName,
+			DstPrefix: "eth",
+		},
+	}
+
+	if ep.Network.IPv4Data != nil && ep.Network.IPv4Data.Gateway != "" {
+		gateway := ep.Network.IPv4Data.Gateway
+		if idx := strings.Index(gateway, "/"); idx != -1 {
+			gateway = gateway[:idx]
+		}
+		resp.Gateway = gateway
+	}
+
+	d.logger.Infof("Container joined network %s via VLAN sub-interface %s (parent %s, vlan %d)", req.NetworkID, subName, ep.Network.Parent, vlanID)
+	return resp, nil
+}
+
+// Leave leaves an endpoint
+func (d *Driver) Leave(req *dnetwork.LeaveRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	d.logger.WithFields(logrus.Fields{
+		"network_id":  req.NetworkID,
+		"endpoint_id": req.EndpointID,
+	}).Info("Leave called")
+
+	ep, exists := d.endpoints[req.EndpointID]
+	if !exists {
+		d.logger.Warnf("Endpoint %s not found", req.EndpointID)
+		return nil // Idempotent
+	}
+
+	if ep.Network.Mode == "vlan" || ep.Network.Mode == "trunk" {
+		if ep.VethName != "" {
+			if err := vlan.DeleteVlanSubinterface(ep.VethName); err != nil {
+				d.logger.WithError(err).Warnf("Failed to delete VLAN sub-interface %s", ep.VethName)
+			}
+			ep.VethName = ""
+			d.persistEndpoint(req.NetworkID, req.EndpointID, ep)
+		}
+		if ep.SandboxKey != "" {
+			delete(d.sandboxes, ep.SandboxKey)
+		}
+		d.logger.Infof("Container left network %s", req.NetworkID)
+		return nil
+	}
+
+	// Remove OVN logical port if it exists
+	if ovnPort := ep.Options["ovn_port"]; ovnPort != "" && d.ovn != nil {
+		if lbOpt := ep.Options["lb_member"]; lbOpt != "" && ep.IPv4Address != "" {
+			for _, lbName := range strings.Split(lbOpt, ",") {
+				lbName = strings.TrimSpace(lbName)
+				if lbName == "" {
+					continue
+				}
+				if err := d.removeLBBackend(ep.NetworkID, lbName, stripCIDR(ep.IPv4Address)); err != nil {
+					d.logger.WithError(err).Warnf("Failed to remove endpoint %s as a backend on load balancer %s", req.EndpointID, lbName)
+				}
+			}
+		}
+		if labelOpt := ep.Options["policy_labels"]; labelOpt != "" && ep.IPv4Address != "" {
+			for _, label := range strings.Split(labelOpt, ",") {
+				label = strings.TrimSpace(label)
+				if label == "" {
+					continue
+				}
+				if err := d.ovn.RemoveAddressFromSet(ovn.AddressSetName(ep.NetworkID, label), stripCIDR(ep.IPv4Address)); err != nil {
+					d.logger.WithError(err).Warnf("Failed to remove endpoint %s from address set for label %s", req.EndpointID, label)
+				}
+			}
+		}
+		if err := d.ovn.RemovePortFromPortGroup(ovn.NetworkPortGroupName(ep.NetworkID), ovnPort); err != nil {
+			d.logger.WithError(err).Warnf("Failed to remove port %s from network policy group", ovnPort)
+		}
+		if sgOpt := ep.Options["security_groups"]; sgOpt != "" {
+			for _, sg := range strings.Split(sgOpt, ",") {
+				sg = strings.TrimSpace(sg)
+				if sg == "" {
+					continue
+				}
+				pgName := ovn.PortGroupName(ep.NetworkID, sg)
+				if err := d.ovn.RemovePortFromPortGroup(pgName, ovnPort); err != nil {
+					d.logger.WithError(err).Warnf("Failed to remove port %s from security group %s", ovnPort, sg)
+				}
+			}
+		}
+		if err := d.ovn.DeleteLogicalPort(ovnPort); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete OVN logical port %s", ovnPort)
+		} else {
+			d.logger.Infof("Deleted OVN logical port %s", ovnPort)
+		}
+		if ep.Network.OVNSwitch != "" {
+			d.releaseFinalizedResource(store.FinalizerSwitch, ep.Network.OVNSwitch, req.EndpointID, d.ovn.DeleteLogicalSwitch)
+		}
+	}
+
+	// Remove the OVS port
+	if ep.PortName != "" {
+		if err := d.ovs.DeletePort(ep.Network.Bridge, ep.PortName); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete OVS port %s", ep.PortName)
+		}
+	}
+
+	// Delete the veth pair
+	if ep.VethName != "" {
+		if err := d.ovs.DeleteVethPair(ep.VethName, ep.PortName); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete veth pair %s", ep.VethName)
+		}
+	}
+
+	// Tear down every secondary interface atomically with the primary
+	for i := range ep.SecondaryInterfaces {
+		d.leaveSecondaryInterface(req.EndpointID, &ep.SecondaryInterfaces[i])
+	}
+
+	// Remove the host SNAT rule; the allocation itself is kept until DeleteEndpoint
+	// so a rejoin gets the same infra-vnet address back
+	if ep.SNATAddress != "" && ep.IPv4Address != "" {
+		if err := d.snat.RemoveRule(ep.IPv4Address, ep.SNATAddress); err != nil {
+			d.logger.WithError(err).Warnf("Failed to remove SNAT rule for endpoint %s", req.EndpointID)
+		}
+	}
+
+	// Remove the elastic IP's DNAT_and_SNAT rule and flip it to not-ready;
+	// the allocation itself is kept until an explicit /eip delete
+	if ep.EIPID != "" {
+		d.unprogramEIP(ep)
+	}
+
+	// Remove any distributed-gateway-mode reroute policy for this endpoint
+	d.removeDistributedGatewayPolicy(ep)
+
+	// Clear the port information but keep the endpoint record
+	ep.PortName = ""
+	ep.VethName = ""
+	d.persistEndpoint(req.NetworkID, req.EndpointID, ep)
+	if ep.SandboxKey != "" {
+		delete(d.sandboxes, ep.SandboxKey)
+	}
+
+	d.logger.Infof("Container left network %s", req.NetworkID)
+	return nil
+}
+
+// DiscoverNew handles discovery notifications
+func (d *Driver) DiscoverNew(req *dnetwork.DiscoveryNotification) error {
+	d.logger.WithField("type", req.DiscoveryType).Debug("DiscoverNew called")
+	return nil
+}
+
+// DiscoverDelete handles discovery delete notifications
+func (d *Driver) DiscoverDelete(req *dnetwork.DiscoveryNotification) error {
+	d.logger.WithField("type", req.DiscoveryType).Debug("DiscoverDelete called")
+	return nil
+}
+
+// portBindingOptionsKey is the libnetwork netlabel under which Docker passes
+// the container's "-p hostPort:containerPort/proto" publish requests to
+// ProgramExternalConnectivity. Its value decodes generically (this driver
+// doesn't vendor libnetwork's types package) as a JSON array mirroring
+// types.PortBinding's wire shape: Proto (IANA protocol number), HostIP,
+// HostPort, Port (the container port).
+const portBindingOptionsKey = "com.docker.network.portmap"
+
+// ianaProtoNames maps the IANA protocol numbers libnetwork's PortBinding
+// uses to the strings OVN's Load_Balancer "protocol" option expects.
+var ianaProtoNames = map[float64]string{
+	6:   "tcp",
+	17:  "udp",
+	132: "sctp",
+}
+
+// parsePortBindings decodes the portBindingOptionsKey option into the
+// bindings this driver should publish, skipping any entry with an
+// unrecognized protocol or a missing port.
+func parsePortBindings(options map[string]interface{}) []types.PortBinding {
+	raw, ok := options[portBindingOptionsKey]
+	if !ok {
+		return nil
+	}
+	entries, ok := raw.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var bindings []types.PortBinding
+	for _, e := range entries {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		proto, ok := ianaProtoNames[protoNumber(m["Proto"])]
+		if !ok {
+			continue
+		}
+		containerPort, _ := m["Port"].(float64)
+		hostPort, _ := m["HostPort"].(float64)
+		if containerPort == 0 || hostPort == 0 {
+			continue
+		}
+		hostIP, _ := m["HostIP"].(string)
+		bindings = append(bindings, types.PortBinding{
+			Proto:         proto,
+			ContainerPort: uint16(containerPort),
+			HostIP:        hostIP,
+			HostPort:      uint16(hostPort),
+		})
+	}
+	return bindings
+}
+
+func protoNumber(v interface{}) float64 {
+	n, _ := v.(float64)
+	return n
+}
+
+// stripCIDR removes a trailing "/prefix" from an address, matching the
+// Gateway-stripping pattern already used for Join's response.
+func stripCIDR(addr string) string {
+	if idx := strings.Index(addr, "/"); idx != -1 {
+		return addr[:idx]
+	}
+	return addr
+}
+
+// ProgramExternalConnectivity publishes any "-p hostPort:containerPort/proto"
+// bindings Docker requested for this endpoint: it allocates the host port(s)
+// via d.portmap, then creates (or reuses) an OVN load balancer VIP per
+// binding, attached to the network's router for north-south reachability and
+// to its switch so sibling containers can reach the VIP too (hairpin/NAT
+// loopback).
+func (d *Driver) ProgramExternalConnectivity(req *dnetwork.ProgramExternalConnectivityRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	d.logger.WithFields(logrus.Fields{
+		"network_id":  req.NetworkID,
+		"endpoint_id": req.EndpointID,
+		"options":     req.Options,
+	}).Info("ProgramExternalConnectivity called")
+
+	ep, exists := d.endpoints[req.EndpointID]
+	if !exists {
+		return fmt.Errorf("endpoint %s not found", req.EndpointID)
+	}
+
+	bindings := parsePortBindings(req.Options)
+	if len(bindings) == 0 {
+		return nil
+	}
+	if d.ovn == nil || ep.Network.OVNSwitch == "" {
+		d.logger.Warnf("Endpoint %s requested published ports but has no OVN switch; skipping", req.EndpointID)
+		return nil
+	}
+
+	for i := range bindings {
+		b := &bindings[i]
+
+		hostPort, hostPortEnd, err := d.portmap.Allocate(req.NetworkID, req.EndpointID, b.Proto, b.HostIP, b.HostPort, b.HostPort, b.ContainerPort)
+		if err != nil {
+			d.portmap.Release(req.NetworkID, req.EndpointID)
+			return fmt.Errorf("failed to allocate host port for %s/%d: %w", b.Proto, b.ContainerPort, err)
+		}
+		b.HostPort, b.HostPortEnd = hostPort, hostPortEnd
+
+		lbName := fmt.Sprintf("lb-%s-%s", req.EndpointID[:12], b.Proto)
+		lbUUID, err := d.ovn.CreateLoadBalancer(lbName, b.Proto)
+		if err != nil {
+			d.portmap.Release(req.NetworkID, req.EndpointID)
+			return fmt.Errorf("failed to create OVN load balancer %s: %w", lbName, err)
+		}
+		b.LBUUID = lbUUID
+
+		// "0.0.0.0"/unset means Docker wants the port reachable on every
+		// host interface, but an OVN Load_Balancer VIP needs one concrete
+		// address - 0.0.0.0 isn't a routable VIP and never actually
+		// receives traffic. Use the tenant router's configured external
+		// gateway address instead, and persist whatever we resolve onto
+		// the binding so Revoke/DeleteEndpoint remove the exact same VIP
+		// without having to recompute it.
+		hostIP := b.HostIP
+		if hostIP == "" || hostIP == "0.0.0.0" || hostIP == "::" {
+			extGW := stripCIDR(ep.Network.Options["ovn.external_gateway"])
+			if extGW == "" {
+				d.portmap.Release(req.NetworkID, req.EndpointID)
+				return fmt.Errorf("cannot publish %s/%d for endpoint %s: network has no ovn.external_gateway configured for its router's external address", b.Proto, b.ContainerPort, req.EndpointID)
+			}
+			hostIP = extGW
+		}
+		b.HostIP = hostIP
+		containerIP := stripCIDR(ep.IPv4Address)
+		if strings.Contains(hostIP, ":") {
+			containerIP = stripCIDR(ep.IPv6Address)
+		}
+		vip := net.JoinHostPort(hostIP, fmt.Sprintf("%d", b.HostPort))
+		backend := net.JoinHostPort(containerIP, fmt.Sprintf("%d", b.ContainerPort))
+
+		if err := d.ovn.AddLBVIP(lbUUID, vip, []string{backend}); err != nil {
+			d.portmap.Release(req.NetworkID, req.EndpointID)
+			return fmt.Errorf("failed to add VIP %s to load balancer %s: %w", vip, lbUUID, err)
+		}
+
+		// North-south: reachable from outside via the network's router.
+		if ep.Network.OVNRouter != "" {
+			if err := d.ovn.AttachLBToRouter(lbUUID, ep.Network.OVNRouter); err != nil {
+				d.logger.WithError(err).Warnf("Failed to attach load balancer %s to router %s", lbUUID, ep.Network.OVNRouter)
+			}
+		}
+		// East-west/hairpin: sibling containers on the same switch can also reach the VIP.
+		if err := d.ovn.AttachLBToSwitch(lbUUID, ep.Network.OVNSwitch); err != nil {
+			d.logger.WithError(err).Warnf("Failed to attach load balancer %s to switch %s", lbUUID, ep.Network.OVNSwitch)
+		}
+
+		d.logger.Infof("Published %s %s -> %s for endpoint %s", b.Proto, vip, backend, req.EndpointID)
+	}
+
+	ep.PortBindings = append(ep.PortBindings, bindings...)
+	d.persistEndpoint(req.NetworkID, req.EndpointID, ep)
+	return nil
+}
+
+// RevokeExternalConnectivity tears down any load balancer VIPs and host port
+// allocations ProgramExternalConnectivity set up for this endpoint.
+func (d *Driver) RevokeExternalConnectivity(req *dnetwork.RevokeExternalConnectivityRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	d.logger.WithFields(logrus.Fields{
+		"network_id":  req.NetworkID,
+		"endpoint_id": req.EndpointID,
+	}).Info("RevokeExternalConnectivity called")
+
+	ep, exists := d.endpoints[req.EndpointID]
+	if !exists || len(ep.PortBindings) == 0 {
+		return nil
+	}
+
+	d.releasePortBindingLBs(ep.PortBindings)
+	d.portmap.Release(req.NetworkID, req.EndpointID)
+	ep.PortBindings = nil
+	d.persistEndpoint(req.NetworkID, req.EndpointID, ep)
+
+	return nil
+}
+
+// releasePortBindingLBs removes each binding's VIP from its OVN Load_Balancer
+// row, then deletes that row once every VIP it was given has been removed.
+// A binding's LBUUID is shared by every other binding on the same endpoint
+// for the same protocol (see ProgramExternalConnectivity's lbName), so this
+// only issues one DeleteLoadBalancer per distinct UUID even when several
+// bindings share it; DeleteLoadBalancer also drops the row's lr-lb/ls-lb
+// associations, so nothing needs a separate detach call.
+func (d *Driver) releasePortBindingLBs(bindings []types.PortBinding) {
+	if d.ovn == nil {
+		return
+	}
+
+	var lbUUIDs []string
+	seen := make(map[string]bool)
+	for _, b := range bindings {
+		if b.LBUUID == "" {
+			continue
+		}
+		hostIP := b.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+		vip := net.JoinHostPort(hostIP, fmt.Sprintf("%d", b.HostPort))
+		if err := d.ovn.RemoveLBVIP(b.LBUUID, vip); err != nil {
+			d.logger.WithError(err).Warnf("Failed to remove VIP %s from load balancer %s", vip, b.LBUUID)
+		}
+		if !seen[b.LBUUID] {
+			seen[b.LBUUID] = true
+			lbUUIDs = append(lbUUIDs, b.LBUUID)
+		}
+	}
+	for _, uuid := range lbUUIDs {
+		if err := d.ovn.DeleteLoadBalancer(uuid); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete load balancer %s", uuid)
+		}
+	}
+}
+
+// generateMAC generates a random MAC address
+func generateMAC() string {
+	mac := make([]byte, 6)
+	rand.Read(mac)
+	// Set local bit and unset multicast bit
+	mac[0] = (mac[0] | 0x02) & 0xfe
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x",
+		mac[0], mac[1], mac[2], mac[3], mac[4], mac[5])
+}
+
+// getChassisID gets the OVN chassis ID for this host
+func getChassisID() string {
+	// Try to get from environment first
+	if id := os.Getenv("OVN_CHASSIS_ID"); id != "" {
+		return id
+	}
+
+	// Try to get from OVS database - see ovnchassis.LocalChassisID, which
+	// this wraps so every other OVN chassis lookup in this package (BFD/
+	// gateway-chassis logging, the readiness gate in Join) shares one
+	// implementation with the HA chassis group startup registration check.
+	if id := ovnchassis.LocalChassisID(); id != "" {
+		return id
+	}
+
+	// Fall back to hostname if OVS isn't reachable either
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// checkChassisIDChange compares current against the last chassis ID this
+// instance observed (store.LocalChassisID), logging a warning if it changed
+// since last time - e.g. the host's "external_ids:system-id" was
+// reprovisioned out from under a long-running plugin process. Any
+// gateway-chassis or HA_Chassis_Group entries pinned to the old ID are now
+// stale; this only surfaces that loudly rather than re-pinning them
+// automatically, since doing so safely would mean re-deriving every
+// network's gateway topology from scratch rather than just this one value.
+func (d *Driver) checkChassisIDChange(current string) {
+	previous := d.store.LocalChassisID()
+	if previous != "" && previous != current {
+		d.logger.Warnf("Local OVN chassis id changed from %s to %s; any gateway-chassis or HA chassis group entries pinned to %s are now stale and must be re-pinned manually", previous, current, previous)
+	}
+	if previous != current {
+		d.store.SetLocalChassisID(current)
+	}
+}
+
+// createVlanNetwork creates a "vlan"/"trunk" mode network, which has no OVS
+// bridge or OVN switch of its own: each endpoint gets its own tagged 802.1Q
+// sub-interface of netConfig.Parent, created directly in Join.
+func (d *Driver) createVlanNetwork(req *dnetwork.CreateNetworkRequest, netConfig *types.Network) error {
+	if netConfig.Parent == "" {
+		return fmt.Errorf("mode %s requires a parent option naming the host NIC to trunk", netConfig.Mode)
+	}
+	if netConfig.VLAN == "" {
+		return fmt.Errorf("mode %s requires a vlan option", netConfig.Mode)
+	}
+
+	d.networks[req.NetworkID] = netConfig
+
+	vlanID := 0
+	fmt.Sscanf(netConfig.VLAN, "%d", &vlanID)
+
+	storeInfo := &store.NetworkInfo{
+		ID:      req.NetworkID,
+		Name:    req.NetworkID,
+		Mode:    netConfig.Mode,
+		Parent:  netConfig.Parent,
+		VLAN:    vlanID,
+		Options: netConfig.Options,
+	}
+	if len(req.IPv4Data) > 0 {
+		ipamData, _ := json.Marshal(req.IPv4Data[0])
+		storeInfo.IPAMData = ipamData
+	}
+	if err := d.store.SaveNetwork(storeInfo); err != nil {
+		d.logger.WithError(err).Warn("Failed to persist network to store")
+	}
+
+	d.logger.Infof("Network %s created in %s mode on parent %s (vlan %s)", req.NetworkID, netConfig.Mode, netConfig.Parent, netConfig.VLAN)
+	return nil
+}
+
+// createTransitNetwork creates a transit network with gateway router
+func (d *Driver) createTransitNetwork(req *dnetwork.CreateNetworkRequest, netConfig *types.Network) error {
+	d.logger.Infof("Creating transit network %s", req.NetworkID)
+
+	// Ensure OVN client is initialized
+	nbConn := netConfig.Options["ovn.nb_connection"]
+	sbConn := netConfig.Options["ovn.sb_connection"]
+
+	if nbConn == "" || sbConn == "" {
+		return fmt.Errorf("transit network requires ovn.nb_connection and ovn.sb_connection")
+	}
+
+	// Check if auto-create is enabled
+	autoCreate := netConfig.Options["ovn.auto_create"] == "true"
+	transitNetwork := netConfig.Options["ovn.transit_overlay_network"] // optional custom network
+
+	// Ensure OVN central is running (create if needed and enabled)
+	if err := d.ensureOVNCentral(nbConn, sbConn, autoCreate, transitNetwork); err != nil {
+		return fmt.Errorf("failed to ensure OVN central: %w", err)
+	}
+
+	if d.ovn == nil {
+		ovnClient, err := ovn.NewClient(nbConn, sbConn)
+		if err != nil {
+			return fmt.Errorf("failed to connect to OVN: %w", err)
+		}
+		d.ovn = ovnClient
+		d.reconcileWithOVN()
+	}
+
+	// Use network name as switch name if not specified
+	switchName := netConfig.OVNSwitch
+	if switchName == "" {
+		switchName = fmt.Sprintf("ls-transit-%s", req.NetworkID[:12])
+		netConfig.OVNSwitch = switchName
+	}
+
+	// Create the transit logical switch
+	ovnOptions := map[string]string{
+		"network_id": req.NetworkID,
+		"role":       "transit",
+	}
+	if err := d.ovn.CreateLogicalSwitch(switchName, ovnOptions); err != nil {
+		return fmt.Errorf("failed to create transit switch: %w", err)
+	}
+	if err := d.store.AddFinalizer(store.FinalizerSwitch, switchName, req.NetworkID); err != nil {
+		d.logger.WithError(err).Warnf("Failed to add finalizer for transit switch %s", switchName)
+	}
+
+	// Create gateway router
+	gatewayRouter := "lr-gateway"
+	routerOpts := map[string]string{
+		"role": "gateway",
+	}
+	if err := d.ovn.CreateLogicalRouter(gatewayRouter, routerOpts); err != nil {
+		return fmt.Errorf("failed to create gateway router: %w", err)
+	}
+
+	// Connect gateway router to transit network - "centralized" (the
+	// default) gives it one port, optionally HA'd via gateway-chassis across
+	// "ovn.external_gateway_nodes"; "distributed" gives every listed chassis
+	// its own port instead, so Join can reroute each endpoint's egress to
+	// whichever one lives on its own chassis.
+	mode := netConfig.Options["ovn.external_gateway_mode"]
+	if mode == "" {
+		mode = "centralized"
+	}
+	netConfig.Options["ovn.external_gateway_mode"] = mode
+	gatewayNodes := parseGatewayNodes(netConfig.Options["ovn.external_gateway_nodes"])
+
+	if mode == "distributed" {
+		if err := d.createDistributedGatewayPorts(gatewayRouter, switchName, gatewayNodes); err != nil {
+			return err
+		}
+	} else if err := d.createCentralizedGatewayPort(gatewayRouter, switchName, netConfig, gatewayNodes); err != nil {
+		return err
+	}
+
+	// Add external gateway route if specified
+	if extGW := netConfig.Options["ovn.external_gateway"]; extGW != "" {
+		d.logger.Infof("Adding default route to external gateway %s", extGW)
+		if err := d.ovn.AddStaticRoute(gatewayRouter, "0.0.0.0/0", extGW); err != nil {
+			// Check if error is about duplicate route
+			if !strings.Contains(err.Error(), "duplicate prefix") {
+				return fmt.Errorf("failed to add default route: %w", err)
+			}
+			d.logger.Infof("Default route already exists on gateway router")
+		}
+		// Don't pre-create the gateway port - it will be created when the NAT gateway container joins
+		// Store the gateway IP so we can identify it later
+		netConfig.Options["external_gateway_ip"] = strings.Split(extGW, "/")[0]
+	}
+
+	// Store the network configuration
+	d.networks[req.NetworkID] = netConfig
+
+	d.logger.Infof("Transit network %s created successfully", req.NetworkID)
+	return nil
+}
+
+// distributedRouterRefCount returns how many currently-known networks,
+// other than excludeNetworkID, reference the distributed router drName via
+// ovn.distributed_router - so attachToDistributedRouter can tell whether
+// it's creating the first attachment and DeleteNetwork can tell whether
+// it's tearing down the last one.
+func (d *Driver) distributedRouterRefCount(drName, excludeNetworkID string) int {
+	count := 0
+	for id, net := range d.networks {
+		if id == excludeNetworkID {
+			continue
+		}
+		if net.Options["ovn.distributed_router"] == drName {
+			count++
+		}
+	}
+	return count
+}
+
+// hashedMAC derives a stable locally-administered MAC from a hash of seed,
+// for callers that need a deterministic-but-collision-resistant address
+// instead of a single hardcoded one - e.g. a distributed router's port onto
+// each switch it connects to, or a gateway LRP for an elastic IP.
+func hashedMAC(seed string) string {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	sum := h.Sum64()
+	return fmt.Sprintf("02:00:%02x:%02x:%02x:%02x", byte(sum>>24), byte(sum>>16), byte(sum>>8), byte(sum))
+}
+
+// routerLinkSubnet deterministically derives a /30 point-to-point link
+// subnet for a pair of logical routers from a hash of their sorted names, so
+// two networks declaring the same pair via "ovn.router.peer" in either order
+// land on the same link regardless of which side runs CreateNetwork first.
+// Addresses are drawn from the 169.254.0.0/16 link-local range, which OVN
+// routes but Docker never hands out as a subnet.
+func routerLinkSubnet(routerA, routerB string) (ipA, ipB string) {
+	names := []string{routerA, routerB}
+	sort.Strings(names)
+
+	h := fnv.New32a()
+	h.Write([]byte(names[0] + "|" + names[1]))
+	sum := h.Sum32()
+
+	b2 := byte(sum >> 8)
+	b3 := byte(sum) &^ 0x03 // align to a /30 boundary, leaving .1 and .2 as host addresses
+	return fmt.Sprintf("169.254.%d.%d/30", b2, b3+1), fmt.Sprintf("169.254.%d.%d/30", b2, b3+2)
+}
+
+// peerLogicalRouters connects routerA and routerB with a dedicated
+// LRP-to-LRP patch link - no switch involved - on a deterministic /30
+// link-local subnet (see routerLinkSubnet), for the static topology declared
+// by a network's "ovn.router.peer" option.
+func (d *Driver) peerLogicalRouters(routerA, routerB string) error {
+	portA := fmt.Sprintf("rp-%s-%s", routerA, routerB)
+	portB := fmt.Sprintf("rp-%s-%s", routerB, routerA)
+	ipA, ipB := routerLinkSubnet(routerA, routerB)
+
+	if err := d.ovn.CreateLogicalRouterPeerPort(routerA, portA, hashedMAC(portA), []string{ipA}, portB); err != nil {
+		return fmt.Errorf("failed to create router peer port %s: %w", portA, err)
+	}
+	if err := d.ovn.CreateLogicalRouterPeerPort(routerB, portB, hashedMAC(portB), []string{ipB}, portA); err != nil {
+		return fmt.Errorf("failed to create router peer port %s: %w", portB, err)
+	}
+
+	d.logger.Infof("Peered router %s with %s", routerA, routerB)
+	return nil
+}
+
+// attachToDistributedRouter creates the distributed router drName -
+// idempotently, only on the first switch that references it - then
+// connects netConfig's switch to it via an auto-generated LRP/switch-port
+// pair, and programs a per-tenant SNAT so east-west traffic between tenant
+// switches routed through the DR carries the tenant's gateway address
+// rather than leaking its internal addressing across switches.
+func (d *Driver) attachToDistributedRouter(drName string, netConfig *types.Network) error {
+	if d.distributedRouterRefCount(drName, "") == 0 {
+		routerOpts := map[string]string{"role": "distributed"}
+		if err := d.ovn.CreateLogicalRouter(drName, routerOpts); err != nil {
+			return fmt.Errorf("failed to create distributed router %s: %w", drName, err)
+		}
+		d.logger.Infof("Created distributed router %s", drName)
+	}
+
+	var networks []string
+	if netConfig.IPv4Data != nil {
+		networks = append(networks, netConfig.IPv4Data.Gateway)
+	}
+	if netConfig.IPv6Data != nil {
+		networks = append(networks, netConfig.IPv6Data.Gateway)
+	}
+	if len(networks) == 0 {
+		return fmt.Errorf("switch %s has no IPv4Data/IPv6Data to attach to distributed router %s", netConfig.OVNSwitch, drName)
+	}
+
+	routerPort := fmt.Sprintf("dr-rp-%s", netConfig.OVNSwitch)
+	routerMAC := hashedMAC(netConfig.OVNSwitch)
+	switchPort := fmt.Sprintf("dr-sp-%s-%s", drName, netConfig.OVNSwitch)
+
+	if err := d.ovn.AttachSwitchToRouter(netConfig.OVNSwitch, switchPort, drName, routerPort, routerMAC, networks); err != nil {
+		return fmt.Errorf("failed to attach switch to distributed router: %w", err)
+	}
+
+	if netConfig.TenantID != "" && netConfig.IPv4Data != nil {
+		if err := d.ovn.AddSNAT(drName, netConfig.IPv4Data.Pool, netConfig.IPv4Data.Gateway); err != nil {
+			d.logger.WithError(err).Warnf("Failed to add SNAT on distributed router %s for tenant %s", drName, netConfig.TenantID)
+		}
+	}
+
+	d.logger.Infof("Attached switch %s to distributed router %s via port %s", netConfig.OVNSwitch, drName, routerPort)
+	return nil
+}
+
+// detachFromDistributedRouter removes netConfig's LRP/switch-port pair from
+// the distributed router drName, and garbage-collects the DR itself once
+// this was the last switch referencing it.
+func (d *Driver) detachFromDistributedRouter(drName string, netConfig *types.Network) {
+	routerPort := fmt.Sprintf("dr-rp-%s", netConfig.OVNSwitch)
+	if err := d.ovn.DeleteLogicalRouterPort(routerPort); err != nil {
+		d.logger.WithError(err).Warnf("Failed to delete distributed router port %s", routerPort)
+	}
+
+	if netConfig.IPv4Data != nil {
+		if err := d.ovn.DeleteSNAT(drName, netConfig.IPv4Data.Pool); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete SNAT for %s on distributed router %s", netConfig.IPv4Data.Pool, drName)
+		}
+	}
+
+	if d.distributedRouterRefCount(drName, netConfig.ID) == 0 {
+		if err := d.ovn.DeleteLogicalRouter(drName); err != nil {
+			d.logger.WithError(err).Warnf("Failed to delete distributed router %s", drName)
+		} else {
+			d.logger.Infof("Deleted distributed router %s, last switch detached", drName)
+		}
+	}
+}
+
+// releaseFinalizedResource removes ownerID from resource's finalizer and,
+// if that was the last owner, calls del to actually remove the underlying
+// OVN object - the ovn4nfv-k8s-plugin-style finalizer pattern that replaces
+// DeleteNetwork's old "just leave shared OVN resources behind" behavior.
+func (d *Driver) releaseFinalizedResource(kind store.FinalizerKind, resource, ownerID string, del func(string) error) {
+	empty, err := d.store.RemoveFinalizer(kind, resource, ownerID)
+	if err != nil {
+		d.logger.WithError(err).Warnf("Failed to remove finalizer owner %s from %s %s", ownerID, kind, resource)
+		return
+	}
+	if !empty {
+		d.logger.Infof("%s %s still in use by other owners, not deleting", kind, resource)
+		return
+	}
+	if err := del(resource); err != nil {
+		d.logger.WithError(err).Warnf("Failed to delete %s %s after last owner detached", kind, resource)
+		return
+	}
+	d.logger.Infof("Deleted %s %s, last owner %s detached", kind, resource, ownerID)
+}
+
+// ReconcileFinalizers reclaims OVN resources whose last owning network or
+// endpoint disappeared while the plugin was down - e.g. a crash between
+// RemoveFinalizer persisting and the OVN delete actually running. Like
+// store.Reconcile, it can't run until d.ovn is connected, which (per
+// reconcileWithOVN) doesn't happen until the first network with an
+// ovn.switch is created - so callers invoking this directly (rather than
+// via reconcileWithOVN) should do so after that has occurred.
+func (d *Driver) ReconcileFinalizers() error {
+	d.Lock()
+	defer d.Unlock()
+	return d.reconcileFinalizersLocked()
+}
+
+// reconcileFinalizersLocked is ReconcileFinalizers' body, factored out so
+// reconcileWithOVN (itself always called with d's lock already held, from
+// inside CreateNetwork) can invoke it without deadlocking.
+func (d *Driver) reconcileFinalizersLocked() error {
+	if d.ovn == nil {
+		return nil
+	}
+
+	liveOwners := make(map[string]bool, len(d.networks)+len(d.endpoints))
+	for id := range d.networks {
+		liveOwners[id] = true
+	}
+	for id := range d.endpoints {
+		liveOwners[id] = true
+	}
+
+	for _, f := range d.store.ListFinalizers() {
+		staleOwners := make([]string, 0, len(f.Owners))
+		for owner := range f.Owners {
+			if !liveOwners[owner] {
+				staleOwners = append(staleOwners, owner)
+			}
+		}
+		if len(staleOwners) == 0 {
+			continue
+		}
+
+		var del func(string) error
+		switch f.Kind {
+		case store.FinalizerSwitch:
+			del = d.ovn.DeleteLogicalSwitch
+		case store.FinalizerRouter:
+			del = d.ovn.DeleteLogicalRouter
+		case store.FinalizerDHCP:
+			del = d.ovn.DeleteDHCPOptions
+		default:
+			d.logger.Warnf("Unknown finalizer kind %q for resource %s, skipping", f.Kind, f.Resource)
+			continue
+		}
+
+		for _, owner := range staleOwners {
+			d.releaseFinalizedResource(f.Kind, f.Resource, owner, del)
+		}
+	}
+	return nil
+}
+
+// transitPortID is the store key for a VPC router's pkg/ipam allocation on
+// a transit network: transitNetworkID:router.
+func transitPortID(transitNetworkID, router string) string {
+	return fmt.Sprintf("%s:%s", transitNetworkID, router)
+}
+
+// vpcSubnets collects the IPv4 pool of every currently known network whose
+// OVNRouter is vpcRouter - the VPC subnet set connectToTransitNetwork routes
+// to vpcRouter from the gateway router, replacing the old vpc-a/vpc-b
+// hardcoded mapping.
+func (d *Driver) vpcSubnets(vpcRouter string) []string {
+	var subnets []string
+	seen := make(map[string]bool)
+	for _, net := range d.networks {
+		if net.OVNRouter != vpcRouter || net.IPv4Data == nil || net.IPv4Data.Pool == "" {
+			continue
+		}
+		if !seen[net.IPv4Data.Pool] {
+			seen[net.IPv4Data.Pool] = true
+			subnets = append(subnets, net.IPv4Data.Pool)
+		}
+	}
+	return subnets
+}
+
+// connectToTransitNetwork connects a VPC router to the transit network,
+// allocating its router port address/MAC via d.ipam instead of the
+// vpc-a/vpc-b string match this used to hardcode.
+func (d *Driver) connectToTransitNetwork(vpcRouter, transitNetName string, netConfig *types.Network) error {
+	transitNet := d.findTransitNetwork()
+	if transitNet == nil {
+		return fmt.Errorf("transit network %s not found", transitNetName)
+	}
+	if transitNet.IPv4Data == nil || transitNet.IPv4Data.Pool == "" {
+		return fmt.Errorf("transit network %s has no IPv4 pool configured", transitNetName)
+	}
+
+	// netConfig's own pool isn't registered in d.networks yet at this point
+	// in CreateNetwork, so vpcSubnets alone would miss it on the first
+	// network to attach to vpcRouter.
+	subnets := d.vpcSubnets(vpcRouter)
+	if netConfig.IPv4Data != nil && netConfig.IPv4Data.Pool != "" {
+		has := false
+		for _, s := range subnets {
+			if s == netConfig.IPv4Data.Pool {
+				has = true
+				break
+			}
+		}
+		if !has {
+			subnets = append(subnets, netConfig.IPv4Data.Pool)
+		}
+	}
+
+	alloc, err := d.ipam.Allocate(transitNet.ID, transitNet.IPv4Data.Pool, vpcRouter, subnets)
+	if err != nil {
+		return fmt.Errorf("failed to allocate transit network address for router %s: %w", vpcRouter, err)
+	}
+	if err := d.store.SaveTransitPort(&store.TransitPortInfo{
+		ID:               transitPortID(transitNet.ID, vpcRouter),
+		TransitNetworkID: transitNet.ID,
+		Router:           vpcRouter,
+		IP:               alloc.IP,
+		MAC:              alloc.MAC,
+		Subnets:          alloc.Subnets,
+	}); err != nil {
+		d.logger.WithError(err).Warn("Failed to persist transit network port allocation")
+	}
+
+	// Create router port on transit network
+	routerPort := fmt.Sprintf("rp-%s-transit", vpcRouter)
+
+	if err := d.ovn.CreateLogicalRouterPort(
+		vpcRouter,
+		routerPort,
+		alloc.MAC,
+		[]string{alloc.IP},
+	); err != nil {
+		return fmt.Errorf("failed to create router port on transit: %w", err)
+	}
+
+	// Create switch port on transit network
+	switchPort := fmt.Sprintf("sp-transit-%s", vpcRouter)
+	switchPortOpts := map[string]string{
+		"type":        "router",
+		"router-port": routerPort,
+	}
+
+	if err := d.ovn.CreateLogicalPort(
+		transitNet.OVNSwitch,
+		switchPort,
+		"", "", // No MAC/IP for router ports
+		switchPortOpts,
+	); err != nil {
+		return fmt.Errorf("failed to create switch port on transit: %w", err)
+	}
+
+	transitIP := strings.Split(alloc.IP, "/")[0]
+
+	// Add default route via gateway router. In distributed gateway mode
+	// there's no single shared gateway port; use the first configured
+	// gateway node's own transit address as the nexthop instead.
+	gatewayIP := firstHostIP(transitNet.IPv4Data.Pool)
+	if transitNet.Options["ovn.external_gateway_mode"] == "distributed" {
+		if nodes := parseGatewayNodes(transitNet.Options["ovn.external_gateway_nodes"]); len(nodes) > 0 && nodes[0].IP != "" {
+			gatewayIP = strings.Split(nodes[0].IP, "/")[0]
+		}
+	}
+	if err := d.ovn.AddStaticRoute(vpcRouter, "0.0.0.0/0", gatewayIP); err != nil {
+		// Check if error is about duplicate route (multiple networks on same VPC router)
+		if !strings.Contains(err.Error(), "duplicate prefix") {
+			return fmt.Errorf("failed to add default route: %w", err)
+		}
+		d.logger.Infof("Default route already exists on router %s", vpcRouter)
+	}
+
+	// Add routes on the gateway router for every subnet this VPC router serves
+	for _, vpcSubnet := range alloc.Subnets {
+		if err := d.ovn.AddStaticRoute("lr-gateway", vpcSubnet, transitIP); err != nil {
+			// Check if error is about duplicate route (multiple networks from same VPC)
+			if !strings.Contains(err.Error(), "duplicate prefix") {
+				return fmt.Errorf("failed to add route for VPC subnet %s: %w", vpcSubnet, err)
+			}
+			d.logger.Infof("Route for VPC subnet %s already exists on gateway router", vpcSubnet)
+		}
+	}
+
+	d.logger.Infof("Connected router %s to transit network", vpcRouter)
+	return nil
+}
+
+// firstHostIP returns the .1 address of pool's subnet (no prefix), the
+// convention createCentralizedGatewayPort uses for the shared gateway
+// router's own port address.
+func firstHostIP(pool string) string {
+	parts := strings.SplitN(pool, "/", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	ipParts := strings.Split(parts[0], ".")
+	if len(ipParts) != 4 {
+		return ""
+	}
+	ipParts[3] = "1"
+	return strings.Join(ipParts, ".")
+}
+
+// isU2O reports whether net is configured for u2o (underlay-to-overlay)
+// interconnection - kube-ovn-style bridging of the overlay subnet straight
+// to a physical VLAN instead of only ever NATing through a gateway router.
+func isU2O(net *types.Network) bool {
+	return net.Options["ovn.role"] == "u2o" || net.Options["ovn.u2o_interconnection"] == "true"
+}
+
+// u2oLocalnetPort and u2oRouterPort name the extra logical switch port and
+// router port setupU2OInterconnection creates, so CreateNetwork/DeleteNetwork
+// and Join all agree on them without threading the names through.
+func u2oLocalnetPort(ovnSwitch string) string {
+	return fmt.Sprintf("u2o-localnet-%s", ovnSwitch)
+}
 
-	ep, exists := d.endpoints[req.EndpointID]
-	if !exists {
-		d.logger.Warnf("Endpoint %s not found", req.EndpointID)
-		return nil // Idempotent
+func u2oRouterPort(router string) string {
+	return fmt.Sprintf("rp-u2o-%s", router)
+}
+
+// setupU2OInterconnection provisions a type=localnet logical switch port on
+// netConfig's switch, pinned to ovn.physical_network, and a dedicated router
+// port onto it with a stable MAC/IP - taken from ovn.u2o_interconnection_ip/
+// ovn.u2o_interconnection_mac if set, otherwise allocated via the IPAM
+// subsystem and written back into netConfig.Options, which CreateNetwork
+// persists to the store alongside the rest of the network's options, so a
+// restart reuses the same address instead of minting a new one.
+func (d *Driver) setupU2OInterconnection(networkID string, netConfig *types.Network) error {
+	physNet := netConfig.Options["ovn.physical_network"]
+	if physNet == "" {
+		return fmt.Errorf("u2o interconnection requires ovn.physical_network to be set")
 	}
 
-	// Remove OVN logical port if it exists
-	if ovnPort := ep.Options["ovn_port"]; ovnPort != "" && d.ovn != nil {
-		if err := d.ovn.DeleteLogicalPort(ovnPort); err != nil {
-			d.logger.WithError(err).Warnf("Failed to delete OVN logical port %s", ovnPort)
-		} else {
-			d.logger.Infof("Deleted OVN logical port %s", ovnPort)
-		}
+	localnetPort := u2oLocalnetPort(netConfig.OVNSwitch)
+	localnetOpts := map[string]string{
+		"type":         "localnet",
+		"network_name": physNet,
+	}
+	if err := d.ovn.CreateLogicalPort(netConfig.OVNSwitch, localnetPort, "", "", localnetOpts); err != nil {
+		return fmt.Errorf("failed to create u2o localnet port: %w", err)
 	}
 
-	// Remove the OVS port
-	if ep.PortName != "" {
-		if err := d.ovs.DeletePort(ep.Network.Bridge, ep.PortName); err != nil {
-			d.logger.WithError(err).Warnf("Failed to delete OVS port %s", ep.PortName)
+	ip := netConfig.Options["ovn.u2o_interconnection_ip"]
+	mac := netConfig.Options["ovn.u2o_interconnection_mac"]
+	if ip == "" || mac == "" {
+		alloc, err := d.ipam.Allocate(networkID, netConfig.IPv4Data.Pool, u2oAllocationKey(netConfig.OVNRouter), nil)
+		if err != nil {
+			return fmt.Errorf("failed to allocate u2o interconnection address: %w", err)
+		}
+		ip = alloc.IP
+		mac = hashedMAC(networkID + "-u2o")
+		netConfig.Options["ovn.u2o_interconnection_ip"] = ip
+		netConfig.Options["ovn.u2o_interconnection_mac"] = mac
+		if err := d.store.SaveTransitPort(&store.TransitPortInfo{
+			ID:               transitPortID(networkID, u2oAllocationKey(netConfig.OVNRouter)),
+			TransitNetworkID: networkID,
+			Router:           u2oAllocationKey(netConfig.OVNRouter),
+			IP:               ip,
+			MAC:              mac,
+		}); err != nil {
+			d.logger.WithError(err).Warn("Failed to persist u2o interconnection address")
 		}
 	}
 
-	// Delete the veth pair
-	if ep.VethName != "" {
-		if err := d.ovs.DeleteVethPair(ep.VethName, ep.PortName); err != nil {
-			d.logger.WithError(err).Warnf("Failed to delete veth pair %s", ep.VethName)
-		}
+	routerPort := u2oRouterPort(netConfig.OVNRouter)
+	if err := d.ovn.CreateLogicalRouterPort(netConfig.OVNRouter, routerPort, mac, []string{ip}); err != nil {
+		return fmt.Errorf("failed to create u2o router port: %w", err)
 	}
 
-	// Clear the port information but keep the endpoint record
-	ep.PortName = ""
-	ep.VethName = ""
+	switchPort := fmt.Sprintf("sp-%s", routerPort)
+	switchPortOpts := map[string]string{
+		"type":        "router",
+		"router-port": routerPort,
+	}
+	if err := d.ovn.CreateLogicalPort(netConfig.OVNSwitch, switchPort, "", "", switchPortOpts); err != nil {
+		return fmt.Errorf("failed to create u2o router switch port: %w", err)
+	}
 
-	d.logger.Infof("Container left network %s", req.NetworkID)
+	d.logger.Infof("U2O interconnection provisioned for network %s on physical network %s", networkID, physNet)
 	return nil
 }
 
-// DiscoverNew handles discovery notifications
-func (d *Driver) DiscoverNew(req *dnetwork.DiscoveryNotification) error {
-	d.logger.WithField("type", req.DiscoveryType).Debug("DiscoverNew called")
-	return nil
+// u2oAllocationKey namespaces a u2o interconnection's pkg/ipam allocation
+// under its own router key, distinct from that same router's transit
+// network allocation (see connectToTransitNetwork), so the two don't collide
+// in the allocator's per-transit-network "used" set.
+func u2oAllocationKey(router string) string {
+	return "u2o:" + router
 }
 
-// DiscoverDelete handles discovery delete notifications
-func (d *Driver) DiscoverDelete(req *dnetwork.DiscoveryNotification) error {
-	d.logger.WithField("type", req.DiscoveryType).Debug("DiscoverDelete called")
-	return nil
-}
+// teardownU2OInterconnection removes the localnet port and dedicated router
+// port setupU2OInterconnection created, and frees the address/MAC it
+// allocated, mirroring connectToTransitNetwork's teardown in DeleteNetwork.
+func (d *Driver) teardownU2OInterconnection(networkID string, net *types.Network) {
+	routerPort := u2oRouterPort(net.OVNRouter)
+	if err := d.ovn.DeleteLogicalPort(u2oLocalnetPort(net.OVNSwitch)); err != nil {
+		d.logger.WithError(err).Warn("Failed to delete u2o localnet port")
+	}
+	if err := d.ovn.DeleteLogicalPort(fmt.Sprintf("sp-%s", routerPort)); err != nil {
+		d.logger.WithError(err).Warn("Failed to delete u2o router switch port")
+	}
+	if err := d.ovn.DeleteLogicalRouterPort(routerPort); err != nil {
+		d.logger.WithError(err).Warn("Failed to delete u2o router port")
+	}
 
-// ProgramExternalConnectivity programs external connectivity
-func (d *Driver) ProgramExternalConnectivity(req *dnetwork.ProgramExternalConnectivityRequest) error {
-	d.logger.WithFields(logrus.Fields{
-		"network_id":  req.NetworkID,
-		"endpoint_id": req.EndpointID,
-		"options":     req.Options,
-	}).Debug("ProgramExternalConnectivity called")
-	// External connectivity will be handled by OVS/OVN
-	return nil
+	d.ipam.Release(networkID, u2oAllocationKey(net.OVNRouter))
+	if err := d.store.DeleteTransitPort(transitPortID(networkID, u2oAllocationKey(net.OVNRouter))); err != nil {
+		d.logger.WithError(err).Warn("Failed to remove u2o interconnection address from store")
+	}
 }
 
-// RevokeExternalConnectivity revokes external connectivity
-func (d *Driver) RevokeExternalConnectivity(req *dnetwork.RevokeExternalConnectivityRequest) error {
-	d.logger.WithFields(logrus.Fields{
-		"network_id":  req.NetworkID,
-		"endpoint_id": req.EndpointID,
-	}).Debug("RevokeExternalConnectivity called")
+// findTransitNetwork returns the shared transit network (ovn.role=transit),
+// or nil if none has been created yet - gateway mode is a property of this
+// network rather than each VPC, so callers on both the create path
+// (connectToTransitNetwork) and the per-endpoint path (applyDistributedGatewayPolicy)
+// look it up the same way instead of threading it through as a parameter.
+func (d *Driver) findTransitNetwork() *types.Network {
+	for _, net := range d.networks {
+		if net.Options["ovn.role"] == "transit" {
+			return net
+		}
+	}
 	return nil
 }
 
-// generateMAC generates a random MAC address
-func generateMAC() string {
-	mac := make([]byte, 6)
-	rand.Read(mac)
-	// Set local bit and unset multicast bit
-	mac[0] = (mac[0] | 0x02) & 0xfe
-	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x",
-		mac[0], mac[1], mac[2], mac[3], mac[4], mac[5])
+// gatewayNode is one chassis listed in a transit network's
+// "ovn.external_gateway_nodes" option, optionally paired with the transit
+// subnet IP its dedicated gateway router port should use in distributed mode.
+type gatewayNode struct {
+	Chassis string
+	IP      string // only set/used in distributed mode
 }
 
-// getChassisID gets the OVN chassis ID for this host
-func getChassisID() string {
-	// Try to get from environment first
-	if chassis := os.Getenv("OVN_CHASSIS_ID"); chassis != "" {
-		return chassis
+// parseGatewayNodes parses the comma-separated "ovn.external_gateway_nodes"
+// option. Each entry is either a bare chassis ID (centralized mode, where
+// only the chassis matters for gateway-chassis HA ranking) or a
+// "chassis:ip" pair (distributed mode, where ip is the address to assign
+// that chassis's dedicated gateway router port on the transit subnet) -
+// reusing the same colon-tuple convention as the "secondary_networks" option.
+func parseGatewayNodes(opt string) []gatewayNode {
+	if opt == "" {
+		return nil
 	}
-
-	// Try to get from OVS database
-	cmd := exec.Command("ovs-vsctl", "get", "open_vswitch", ".", "external_ids:system-id")
-	output, err := cmd.Output()
-	if err != nil {
-		// Try hostname as fallback
-		hostname, _ := os.Hostname()
-		return hostname
+	var nodes []gatewayNode
+	for _, entry := range strings.Split(opt, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		node := gatewayNode{Chassis: parts[0]}
+		if len(parts) == 2 {
+			node.IP = parts[1]
+		}
+		nodes = append(nodes, node)
 	}
-
-	chassis := strings.TrimSpace(string(output))
-	chassis = strings.Trim(chassis, "\"")
-	return chassis
+	return nodes
 }
 
-// createTransitNetwork creates a transit network with gateway router
-func (d *Driver) createTransitNetwork(req *dnetwork.CreateNetworkRequest, netConfig *types.Network) error {
-	d.logger.Infof("Creating transit network %s", req.NetworkID)
+// reconcileHAChassisGroup points switchPort at the named HA_Chassis_Group
+// declared by netConfig's "ovn.ha_chassis_group" option, creating the group
+// and its "ovn.ha_chassis_group.members" chassis if needed via pkg/ovn/chassis.
+// This is the Logical_Switch_Port-scoped counterpart to SetGatewayChassis's
+// Logical_Router_Port-scoped ranking: a distributed router port typically
+// fails over via gateway-chassis on the LRP, while HA_Chassis_Group ranks
+// failover directly on the switch's own router-type port, for topologies
+// where no dedicated gateway router port exists to hang gateway-chassis off
+// of. No-ops if the network doesn't set "ovn.ha_chassis_group".
+func (d *Driver) reconcileHAChassisGroup(switchPort string, netConfig *types.Network) error {
+	groupName := netConfig.Options["ovn.ha_chassis_group"]
+	if groupName == "" {
+		return nil
+	}
+	members, err := ovnchassis.ParseMembers(netConfig.Options["ovn.ha_chassis_group.members"])
+	if err != nil {
+		return fmt.Errorf("invalid ovn.ha_chassis_group.members: %w", err)
+	}
+	if len(members) == 0 {
+		return fmt.Errorf("ovn.ha_chassis_group %s requires ovn.ha_chassis_group.members", groupName)
+	}
 
-	// Ensure OVN client is initialized
-	nbConn := netConfig.Options["ovn.nb_connection"]
-	sbConn := netConfig.Options["ovn.sb_connection"]
+	// Confirm this host's own chassis - the one chassis this process can
+	// actually speak for - is registered in the Southbound database before
+	// wiring the group, the same readiness gate Join applies before binding
+	// an ordinary port.
+	if localID := getChassisID(); localID != "" {
+		if err := ovnchassis.EnsureRegistered(d.ovn, localID, defaultChassisTimeout); err != nil {
+			return err
+		}
+	}
 
-	if nbConn == "" || sbConn == "" {
-		return fmt.Errorf("transit network requires ovn.nb_connection and ovn.sb_connection")
+	if err := ovnchassis.EnsureGroup(d.ovn, groupName, members); err != nil {
+		return fmt.Errorf("failed to reconcile HA chassis group %s: %w", groupName, err)
+	}
+	if err := ovnchassis.AttachToPort(d.ovn, groupName, switchPort); err != nil {
+		return fmt.Errorf("failed to attach HA chassis group %s to port %s: %w", groupName, switchPort, err)
 	}
 
-	// Check if auto-create is enabled
-	autoCreate := netConfig.Options["ovn.auto_create"] == "true"
-	transitNetwork := netConfig.Options["ovn.transit_overlay_network"] // optional custom network
+	d.logger.Infof("Port %s now failing over via HA chassis group %s", switchPort, groupName)
+	return nil
+}
 
-	// Ensure OVN central is running (create if needed and enabled)
-	if err := d.ensureOVNCentral(nbConn, sbConn, autoCreate, transitNetwork); err != nil {
-		return fmt.Errorf("failed to ensure OVN central: %w", err)
+// distributedGatewayPolicyPriority is the priority used for every
+// per-endpoint logical_router_policy applied by applyDistributedGatewayPolicy -
+// chosen well above OVN's own default routing priorities so it always wins
+// over the router's ordinary routes.
+const distributedGatewayPolicyPriority = 500
+
+// createCentralizedGatewayPort connects gatewayRouter to switchName with a
+// single shared router port, as createTransitNetwork always did before
+// "ovn.external_gateway_mode" existed. When nodes is non-empty the port is
+// additionally pinned across them via gateway-chassis (ranked by list
+// order, first = highest priority) for HA failover. Whenever
+// "ovn.external_gateway" is set, one BFD session on that shared port (not
+// one per chassis) monitors its reachability to the external gateway.
+func (d *Driver) createCentralizedGatewayPort(gatewayRouter, switchName string, netConfig *types.Network, nodes []gatewayNode) error {
+	if netConfig.IPv4Data == nil {
+		return nil
 	}
 
-	if d.ovn == nil {
-		ovnClient, err := ovn.NewClient(nbConn, sbConn)
-		if err != nil {
-			return fmt.Errorf("failed to connect to OVN: %w", err)
+	// Use .1 address for gateway router on transit network
+	gwIP := netConfig.IPv4Data.Gateway
+	if gwIP == "" && netConfig.IPv4Data.Pool != "" {
+		// Extract gateway IP from pool (first IP in subnet)
+		// Parse subnet and use .1 address
+		gwIP = strings.Split(netConfig.IPv4Data.Pool, "/")[0]
+		parts := strings.Split(gwIP, ".")
+		if len(parts) == 4 {
+			parts[3] = "1"
+			gwIP = strings.Join(parts, ".") + "/" + strings.Split(netConfig.IPv4Data.Pool, "/")[1]
 		}
-		d.ovn = ovnClient
 	}
 
-	// Use network name as switch name if not specified
-	switchName := netConfig.OVNSwitch
-	if switchName == "" {
-		switchName = fmt.Sprintf("ls-transit-%s", req.NetworkID[:12])
-		netConfig.OVNSwitch = switchName
+	routerPort := fmt.Sprintf("rp-%s-%s", gatewayRouter, switchName)
+	routerMAC := "02:00:00:00:00:01"
+
+	gwNetworks := []string{gwIP}
+	if netConfig.IPv6Data != nil && netConfig.IPv6Data.Gateway != "" {
+		gwNetworks = append(gwNetworks, netConfig.IPv6Data.Gateway)
 	}
 
-	// Create the transit logical switch
-	ovnOptions := map[string]string{
-		"network_id": req.NetworkID,
-		"role":       "transit",
+	if err := d.ovn.CreateLogicalRouterPort(
+		gatewayRouter,
+		routerPort,
+		routerMAC,
+		gwNetworks,
+	); err != nil {
+		return fmt.Errorf("failed to create gateway router port: %w", err)
 	}
-	if err := d.ovn.CreateLogicalSwitch(switchName, ovnOptions); err != nil {
-		return fmt.Errorf("failed to create transit switch: %w", err)
+
+	// Create switch port for router connection
+	switchPort := fmt.Sprintf("sp-%s-%s", switchName, gatewayRouter)
+	switchPortOpts := map[string]string{
+		"type":        "router",
+		"router-port": routerPort,
 	}
 
-	// Create gateway router
-	gatewayRouter := "lr-gateway"
-	routerOpts := map[string]string{
-		"role": "gateway",
+	if err := d.ovn.CreateLogicalPort(
+		switchName,
+		switchPort,
+		"", "", // No MAC/IP for router ports
+		switchPortOpts,
+	); err != nil {
+		return fmt.Errorf("failed to create switch port for gateway router: %w", err)
 	}
-	if err := d.ovn.CreateLogicalRouter(gatewayRouter, routerOpts); err != nil {
-		return fmt.Errorf("failed to create gateway router: %w", err)
+
+	for i, node := range nodes {
+		if err := d.ovn.SetGatewayChassis(routerPort, node.Chassis, len(nodes)-i); err != nil {
+			d.logger.WithError(err).Warnf("Failed to set gateway chassis %s on %s", node.Chassis, routerPort)
+		}
 	}
 
-	// Connect gateway router to transit network
-	if netConfig.IPv4Data != nil {
-		// Use .1 address for gateway router on transit network
-		gwIP := netConfig.IPv4Data.Gateway
-		if gwIP == "" && netConfig.IPv4Data.Pool != "" {
-			// Extract gateway IP from pool (first IP in subnet)
-			// Parse subnet and use .1 address
-			gwIP = strings.Split(netConfig.IPv4Data.Pool, "/")[0]
-			parts := strings.Split(gwIP, ".")
-			if len(parts) == 4 {
-				parts[3] = "1"
-				gwIP = strings.Join(parts, ".") + "/" + strings.Split(netConfig.IPv4Data.Pool, "/")[1]
-			}
+	// Unlike SetGatewayChassis above, which ranks each node for HA failover on
+	// the one shared centralized port, the BFD session monitors that same
+	// port's reachability to the external gateway - it isn't per-node, so it
+	// only needs to be created once here instead of once per node.
+	if extGW := netConfig.Options["ovn.external_gateway"]; extGW != "" {
+		extGWAddr := strings.Split(extGW, "/")[0]
+		if err := d.ovn.CreateBFD(routerPort, extGWAddr); err != nil {
+			d.logger.WithError(err).Warnf("Failed to create BFD session on %s to %s", routerPort, extGWAddr)
 		}
+	}
 
-		routerPort := fmt.Sprintf("rp-%s-%s", gatewayRouter, switchName)
-		routerMAC := "02:00:00:00:00:01"
+	return nil
+}
 
-		if err := d.ovn.CreateLogicalRouterPort(
-			gatewayRouter,
-			routerPort,
-			routerMAC,
-			[]string{gwIP},
-		); err != nil {
-			return fmt.Errorf("failed to create gateway router port: %w", err)
+// createDistributedGatewayPorts gives every chassis in nodes its own
+// dedicated router port onto switchName, pinned exclusively to that
+// chassis via gateway-chassis, instead of sharing the one port
+// createCentralizedGatewayPort would create. Join pairs each endpoint with
+// its local chassis's port via applyDistributedGatewayPolicy so east-west
+// egress never has to hairpin through a different chassis.
+func (d *Driver) createDistributedGatewayPorts(gatewayRouter, switchName string, nodes []gatewayNode) error {
+	for _, node := range nodes {
+		if node.IP == "" {
+			return fmt.Errorf("distributed gateway mode requires a chassis:ip pair for chassis %s in ovn.external_gateway_nodes", node.Chassis)
 		}
 
-		// Create switch port for router connection
-		switchPort := fmt.Sprintf("sp-%s-%s", switchName, gatewayRouter)
+		routerPort := fmt.Sprintf("rp-%s-%s-%s", gatewayRouter, switchName, node.Chassis)
+		if err := d.ovn.CreateLogicalRouterPort(gatewayRouter, routerPort, hashedMAC(node.Chassis), []string{node.IP}); err != nil {
+			return fmt.Errorf("failed to create distributed gateway router port for chassis %s: %w", node.Chassis, err)
+		}
+		if err := d.ovn.SetGatewayChassis(routerPort, node.Chassis, 100); err != nil {
+			d.logger.WithError(err).Warnf("Failed to pin gateway chassis %s on %s", node.Chassis, routerPort)
+		}
+
+		switchPort := fmt.Sprintf("sp-%s-%s-%s", switchName, gatewayRouter, node.Chassis)
 		switchPortOpts := map[string]string{
 			"type":        "router",
 			"router-port": routerPort,
 		}
-
-		if err := d.ovn.CreateLogicalPort(
-			switchName,
-			switchPort,
-			"", "", // No MAC/IP for router ports
-			switchPortOpts,
-		); err != nil {
-			return fmt.Errorf("failed to create switch port for gateway router: %w", err)
+		if err := d.ovn.CreateLogicalPort(switchName, switchPort, "", "", switchPortOpts); err != nil {
+			return fmt.Errorf("failed to create distributed gateway switch port for chassis %s: %w", node.Chassis, err)
 		}
 	}
+	return nil
+}
 
-	// Add external gateway route if specified
-	if extGW := netConfig.Options["ovn.external_gateway"]; extGW != "" {
-		d.logger.Infof("Adding default route to external gateway %s", extGW)
-		if err := d.ovn.AddStaticRoute(gatewayRouter, "0.0.0.0/0", extGW); err != nil {
-			// Check if error is about duplicate route
-			if !strings.Contains(err.Error(), "duplicate prefix") {
-				return fmt.Errorf("failed to add default route: %w", err)
-			}
-			d.logger.Infof("Default route already exists on gateway router")
-		}
-		// Don't pre-create the gateway port - it will be created when the NAT gateway container joins
-		// Store the gateway IP so we can identify it later
-		netConfig.Options["external_gateway_ip"] = strings.Split(extGW, "/")[0]
+// applyDistributedGatewayPolicy installs a logical_router_policy on ep's VPC
+// router rerouting its own source IP to its local chassis's dedicated
+// gateway port, so its east-west/external egress exits locally instead of
+// hairpinning through whichever chassis owns the shared centralized port.
+// No-ops outside distributed mode, or if the local chassis isn't a
+// configured gateway node.
+func (d *Driver) applyDistributedGatewayPolicy(ep *types.Endpoint) error {
+	if ep.Network.OVNRouter == "" || d.ovn == nil || ep.IPv4Address == "" {
+		return nil
+	}
+	transitNet := d.findTransitNetwork()
+	if transitNet == nil || transitNet.Options["ovn.external_gateway_mode"] != "distributed" {
+		return nil
 	}
 
-	// Store the network configuration
-	d.networks[req.NetworkID] = netConfig
+	chassis := getChassisID()
+	if chassis == "" {
+		return fmt.Errorf("failed to determine local chassis for distributed gateway policy")
+	}
 
-	d.logger.Infof("Transit network %s created successfully", req.NetworkID)
+	var nexthop string
+	for _, node := range parseGatewayNodes(transitNet.Options["ovn.external_gateway_nodes"]) {
+		if node.Chassis == chassis {
+			nexthop = strings.Split(node.IP, "/")[0]
+			break
+		}
+	}
+	if nexthop == "" {
+		return fmt.Errorf("chassis %s is not a configured distributed gateway node", chassis)
+	}
+
+	internalIP := strings.Split(ep.IPv4Address, "/")[0]
+	match := fmt.Sprintf("ip4.src == %s", internalIP)
+	if err := d.ovn.AddLogicalRouterPolicy(ep.Network.OVNRouter, distributedGatewayPolicyPriority, match, "reroute", nexthop); err != nil {
+		return fmt.Errorf("failed to apply distributed gateway policy for endpoint %s: %w", ep.ID, err)
+	}
 	return nil
 }
 
-// connectToTransitNetwork connects a VPC router to the transit network
-func (d *Driver) connectToTransitNetwork(vpcRouter, transitNetName string) error {
-	// Look up the transit network configuration
-	var transitNet *types.Network
-	for _, net := range d.networks {
-		if net.Options["ovn.role"] == "transit" {
-			transitNet = net
-			break
-		}
+// removeDistributedGatewayPolicy removes the logical_router_policy
+// applyDistributedGatewayPolicy installed for ep, if any. It recomputes the
+// match from the endpoint's current state rather than relying on persisted
+// flag, since DeleteLogicalRouterPolicy is already idempotent against a
+// policy that was never created (e.g. centralized mode, or the endpoint's
+// chassis was never a gateway node).
+func (d *Driver) removeDistributedGatewayPolicy(ep *types.Endpoint) {
+	if ep.Network == nil || ep.Network.OVNRouter == "" || d.ovn == nil || ep.IPv4Address == "" {
+		return
+	}
+	transitNet := d.findTransitNetwork()
+	if transitNet == nil || transitNet.Options["ovn.external_gateway_mode"] != "distributed" {
+		return
 	}
 
-	if transitNet == nil {
-		return fmt.Errorf("transit network %s not found", transitNetName)
+	internalIP := strings.Split(ep.IPv4Address, "/")[0]
+	match := fmt.Sprintf("ip4.src == %s", internalIP)
+	if err := d.ovn.DeleteLogicalRouterPolicy(ep.Network.OVNRouter, distributedGatewayPolicyPriority, match); err != nil {
+		d.logger.WithError(err).Warnf("Failed to remove distributed gateway policy for endpoint %s", ep.ID)
 	}
+}
 
-	// Determine the next available IP on the transit network
-	// In production, this would need proper IPAM
-	// For now, use a simple scheme: .10 for vpc-a, .20 for vpc-b, etc.
-	var transitIP string
-	if strings.Contains(vpcRouter, "vpc-a") {
-		transitIP = "192.168.100.10/24"
-	} else if strings.Contains(vpcRouter, "vpc-b") {
-		transitIP = "192.168.100.20/24"
-	} else {
-		// Generate based on hash or sequence
-		transitIP = "192.168.100.100/24"
+// ListNetworks returns the persisted configuration of every network the
+// plugin knows about, so the CNI config generator can build a conflist from
+// it at startup without reaching into the store package directly.
+func (d *Driver) ListNetworks() []*store.NetworkInfo {
+	return d.store.ListNetworks()
+}
+
+// AttachEndpointToNamespace moves the host-side interface Join created for
+// endpointID into the namespace at nsPath, renames it to ifName, and
+// configures its address and default route. Docker's libnetwork does this
+// same move/rename/configure step itself, using the InterfaceName and
+// Gateway returned from Join; a CNI caller has no such daemon, so the CNI
+// server drives it directly through this method instead.
+func (d *Driver) AttachEndpointToNamespace(endpointID, nsPath, ifName string) error {
+	d.RLock()
+	ep, exists := d.endpoints[endpointID]
+	d.RUnlock()
+	if !exists {
+		return fmt.Errorf("endpoint %s not found", endpointID)
+	}
+	if ep.VethName == "" {
+		return fmt.Errorf("endpoint %s has not joined yet", endpointID)
 	}
 
-	// Create router port on transit network
-	routerPort := fmt.Sprintf("rp-%s-transit", vpcRouter)
-	routerMAC := "02:00:00:00:00:10" // Should be unique per router
+	var ipAddr string
+	if ep.IPv4Address != "" {
+		ipAddr = ep.IPv4Address
+	}
 
-	if err := d.ovn.CreateLogicalRouterPort(
-		vpcRouter,
-		routerPort,
-		routerMAC,
-		[]string{transitIP},
-	); err != nil {
-		return fmt.Errorf("failed to create router port on transit: %w", err)
+	var gateway string
+	if ep.Network.IPv4Data != nil && ep.Network.IPv4Data.Gateway != "" {
+		gateway = ep.Network.IPv4Data.Gateway
+		if idx := strings.Index(gateway, "/"); idx != -1 {
+			gateway = gateway[:idx]
+		}
 	}
 
-	// Create switch port on transit network
-	switchPort := fmt.Sprintf("sp-transit-%s", vpcRouter)
-	switchPortOpts := map[string]string{
-		"type":        "router",
-		"router-port": routerPort,
+	if err := d.ovs.ConfigureEndpointInNamespace(ep.VethName, nsPath, ifName, ipAddr, gateway); err != nil {
+		return fmt.Errorf("failed to attach endpoint %s to namespace %s: %w", endpointID, nsPath, err)
 	}
+	return nil
+}
 
-	if err := d.ovn.CreateLogicalPort(
-		transitNet.OVNSwitch,
-		switchPort,
-		"", "", // No MAC/IP for router ports
-		switchPortOpts,
-	); err != nil {
-		return fmt.Errorf("failed to create switch port on transit: %w", err)
+// CreateEIP allocates an elastic IP from cidr and binds it to endpointID -
+// the admin-API equivalent of the "ovn.eip" CreateEndpoint option, for
+// attaching one to an endpoint that's already running without recreating it.
+func (d *Driver) CreateEIP(networkID, endpointID, cidr string) (*store.EIPInfo, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	ep, exists := d.endpoints[endpointID]
+	if !exists {
+		return nil, fmt.Errorf("endpoint %s not found", endpointID)
 	}
 
-	// Add routes for inter-VPC and external connectivity
-	// Add default route via gateway router
-	if err := d.ovn.AddStaticRoute(vpcRouter, "0.0.0.0/0", "192.168.100.1"); err != nil {
-		// Check if error is about duplicate route (multiple networks on same VPC router)
-		if !strings.Contains(err.Error(), "duplicate prefix") {
-			return fmt.Errorf("failed to add default route: %w", err)
+	eipInfo, err := d.allocateEIP(networkID, endpointID, cidr)
+	if err != nil {
+		return nil, err
+	}
+	ep.EIPID = eipInfo.ID
+	d.persistEndpoint(networkID, endpointID, ep)
+
+	if ep.IPv4Address != "" {
+		if err := d.programEIP(ep); err != nil {
+			d.logger.WithError(err).Warnf("Failed to program elastic IP for endpoint %s", endpointID)
 		}
-		d.logger.Infof("Default route already exists on router %s", vpcRouter)
 	}
 
-	// Add routes on gateway router for this VPC's subnet
-	// This would need to be determined from the VPC's networks
-	// For now, use a simple mapping
-	var vpcSubnet string
-	if strings.Contains(vpcRouter, "vpc-a") {
-		vpcSubnet = "10.0.0.0/16"
-	} else if strings.Contains(vpcRouter, "vpc-b") {
-		vpcSubnet = "10.1.0.0/16"
+	return d.store.GetEIP(eipInfo.ID)
+}
+
+// DeleteEIP tears down eipID's DNAT_and_SNAT rule and gateway LRP (if still
+// bound to a live endpoint), frees the external address back to its pool,
+// and removes its status record. This is the only thing that actually frees
+// an elastic IP - Leave and DeleteEndpoint both keep the allocation around
+// so a restarted container gets the same address back.
+func (d *Driver) DeleteEIP(eipID string) error {
+	d.Lock()
+	defer d.Unlock()
+
+	eipInfo, err := d.store.GetEIP(eipID)
+	if err != nil {
+		return fmt.Errorf("elastic IP %s not found: %w", eipID, err)
+	}
+
+	if ep, exists := d.endpoints[eipInfo.EndpointID]; exists {
+		d.unprogramEIP(ep)
+		ep.EIPID = ""
+		d.persistEndpoint(ep.NetworkID, ep.ID, ep)
 	}
 
-	if vpcSubnet != "" {
-		if err := d.ovn.AddStaticRoute("lr-gateway", vpcSubnet, strings.Split(transitIP, "/")[0]); err != nil {
-			// Check if error is about duplicate route (multiple networks from same VPC)
-			if !strings.Contains(err.Error(), "duplicate prefix") {
-				return fmt.Errorf("failed to add route for VPC subnet: %w", err)
-			}
-			d.logger.Infof("Route for VPC subnet %s already exists on gateway router", vpcSubnet)
-		}
+	d.eip.Release(eipInfo.NetworkID, eipInfo.EndpointID)
+	if err := d.store.DeleteEIP(eipID); err != nil {
+		return fmt.Errorf("failed to remove elastic IP %s: %w", eipID, err)
 	}
 
-	d.logger.Infof("Connected router %s to transit network", vpcRouter)
+	d.logger.Infof("Elastic IP %s deleted", eipID)
 	return nil
 }
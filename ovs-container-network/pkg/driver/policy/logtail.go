@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// defaultTailLines bounds how much of the ACL log file TailLog reads when the
+// caller doesn't ask for a specific count, so a large log doesn't get read
+// into memory in full on every request.
+const defaultTailLines = 200
+
+// TailLog returns up to maxLines of the most recent entries in the ACL log
+// file at path (ovn-controller's "log" ACL action writes matching packets
+// there via its syslog/file target), for the /policy/log admin endpoint.
+// maxLines <= 0 uses defaultTailLines. A missing file returns no lines
+// rather than an error, since nothing has logged yet is the common case
+// right after a network's policy is first applied.
+func TailLog(path string, maxLines int) ([]string, error) {
+	if maxLines <= 0 {
+		maxLines = defaultTailLines
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open ACL log file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) > maxLines {
+			lines = lines[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ACL log file %s: %w", path, err)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,178 @@
+// Package policy compiles declarative, label-selector based microsegmentation
+// rules into OVN Address_Set and Port_Group primitives. Where pkg/ovn's
+// SecurityGroupPolicy is an opt-in, named Port_Group a network declares via
+// "ovn.security_groups", this package targets the one network-wide Port_Group
+// every endpoint is unconditionally added to on Join (see
+// ovn.NetworkPortGroupName), with peers matched by label selector through an
+// Address_Set instead of a literal CIDR - the same model kube-ovn's
+// pod-selector network policies use, which is why the wrapper functions below
+// borrow its SetACL/DeleteACL/UpdateAddressSet vocabulary.
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+)
+
+// Rule is one declarative microsegmentation rule, parsed from the JSON array
+// in a network's "ovn.policy" option, or from the sidecar file named by
+// "ovn.policy_file" when set. Selector names a label previously assigned to
+// one or more endpoints via the "policy_labels" endpoint option; traffic
+// to/from any endpoint carrying that label is matched through its
+// Address_Set instead of a literal CIDR. CtState, if set, is passed straight
+// into the match expression (e.g. "ct.new", "ct.est && !ct.rpl"), the same
+// way Action is passed straight through to ovn-nbctl.
+type Rule struct {
+	Direction string `json:"direction"`          // "ingress" or "egress"
+	Selector  string `json:"selector,omitempty"` // peer label; "" matches any address
+	CIDR      string `json:"cidr,omitempty"`     // peer CIDR, used when Selector is unset
+	Protocol  string `json:"protocol,omitempty"` // "tcp", "udp", "icmp", or "" for any
+	PortMin   int    `json:"port_min,omitempty"`
+	PortMax   int    `json:"port_max,omitempty"` // equal to PortMin for a single port
+	CtState   string `json:"ct_state,omitempty"`
+	Action    string `json:"action"` // "allow", "allow-related", "drop", "reject"
+	Priority  int    `json:"priority"`
+	Log       bool   `json:"log,omitempty"`
+}
+
+// ParseRules decodes a network's "ovn.policy" option's JSON rule list, or, if
+// policyFile is non-empty, reads and decodes that file instead. Every
+// structured option in this driver is JSON (see pkg/ovn.ParseACLRules); this
+// repo has no YAML dependency, so the sidecar file follows the same format
+// rather than introducing one. An empty option/file decodes to no rules
+// rather than an error, matching ParseACLRules.
+func ParseRules(raw, policyFile string) ([]Rule, error) {
+	if policyFile != "" {
+		data, err := os.ReadFile(policyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy file %s: %w", policyFile, err)
+		}
+		raw = string(data)
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	var rules []Rule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, fmt.Errorf("invalid policy rule list: %w", err)
+	}
+	return rules, nil
+}
+
+// Hash returns a stable hex-encoded digest of rules' content, so the Driver
+// can compare it against the persisted policy hash and skip reprogramming
+// ACLs that haven't actually changed - the same drift-detection pattern
+// ovn.SecurityGroupPolicy.Hash uses for named security groups.
+func Hash(rules []Rule) string {
+	data, _ := json.Marshal(rules)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// lportDirection maps Rule.Direction onto the OVN ACL direction verb.
+func (r Rule) lportDirection() string {
+	if r.Direction == "egress" {
+		return "from-lport"
+	}
+	return "to-lport"
+}
+
+// match builds the OVN ACL match expression for r, applied to networkID's
+// network-wide port group pgName. Peer selection mirrors
+// ovn.ACLRule.match: ingress traffic's peer is its source address, egress
+// traffic's peer is its destination.
+func (r Rule) match(networkID, pgName string) string {
+	direction := r.lportDirection()
+	portField := "outport"
+	peerField := "ip4.src"
+	if direction == "from-lport" {
+		portField = "inport"
+		peerField = "ip4.dst"
+	}
+
+	clauses := []string{fmt.Sprintf("%s == @%s", portField, pgName)}
+
+	switch {
+	case r.Selector != "":
+		clauses = append(clauses, fmt.Sprintf("%s == @%s", peerField, ovn.AddressSetName(networkID, r.Selector)))
+	case r.CIDR != "":
+		clauses = append(clauses, fmt.Sprintf("%s == %s", peerField, r.CIDR))
+	}
+
+	if r.Protocol != "" {
+		clauses = append(clauses, r.Protocol)
+		if r.PortMin > 0 {
+			if r.PortMax > r.PortMin {
+				clauses = append(clauses, fmt.Sprintf("%s.dst >= %d && %s.dst <= %d", r.Protocol, r.PortMin, r.Protocol, r.PortMax))
+			} else {
+				clauses = append(clauses, fmt.Sprintf("%s.dst == %d", r.Protocol, r.PortMin))
+			}
+		}
+	}
+
+	if r.CtState != "" {
+		clauses = append(clauses, r.CtState)
+	}
+
+	return fmt.Sprintf("(%s)", joinAnd(clauses))
+}
+
+func joinAnd(clauses []string) string {
+	out := clauses[0]
+	for _, c := range clauses[1:] {
+		out += " && " + c
+	}
+	return out
+}
+
+// SetACL compiles rules onto networkID's network-wide port group, clearing
+// whatever ACLs were there before - the same clear-and-recreate strategy
+// ovn.ApplyPolicy uses for named security groups, since ACLs are keyed off a
+// persisted policy hash rather than applied incrementally.
+func SetACL(client *ovn.Client, networkID string, rules []Rule, meterName string) error {
+	pgName := ovn.NetworkPortGroupName(networkID)
+	if err := client.ClearACLs(pgName); err != nil {
+		return fmt.Errorf("failed to clear existing ACLs on port group %s: %w", pgName, err)
+	}
+	for _, r := range rules {
+		if err := client.AddACL(pgName, r.lportDirection(), r.Priority, r.match(networkID, pgName), r.Action, r.Log, meterName); err != nil {
+			return fmt.Errorf("failed to add policy rule to port group %s: %w", pgName, err)
+		}
+	}
+	return nil
+}
+
+// DeleteACL removes every ACL on networkID's network-wide port group without
+// deleting the port group itself, for the case where a network's policy
+// rules are cleared but endpoints remain joined to it.
+func DeleteACL(client *ovn.Client, networkID string) error {
+	return client.ClearACLs(ovn.NetworkPortGroupName(networkID))
+}
+
+// UpdateAddressSet reconciles the Address_Set for label on networkID to
+// contain exactly addresses, creating the set first if it doesn't exist yet.
+// Like SetACL, this recomputes from scratch rather than diffing, since
+// callers only invoke it when a label's full member list is known up front
+// (see reconcileNetworkPolicy); per-endpoint Join/Leave instead add or remove
+// one address at a time via ovn.Client.AddAddressToSet/RemoveAddressFromSet,
+// since they only ever know their own endpoint's address.
+func UpdateAddressSet(client *ovn.Client, networkID, label string, addresses []string) error {
+	name := ovn.AddressSetName(networkID, label)
+	if err := client.DeleteAddressSet(name); err != nil {
+		return fmt.Errorf("failed to reset address set %s: %w", name, err)
+	}
+	if err := client.CreateAddressSet(name); err != nil {
+		return err
+	}
+	for _, addr := range addresses {
+		if err := client.AddAddressToSet(name, addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
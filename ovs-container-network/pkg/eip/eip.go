@@ -0,0 +1,146 @@
+// Package eip manages elastic/floating IP allocations: addresses handed out
+// of a configurable external pool and bound to one endpoint's internal
+// address via an OVN DNAT_and_SNAT rule, the way Kube-OVN's ovn-eip CRD
+// gives a pod a persistent public address. Allocation bookkeeping mirrors
+// pkg/snat's infra-vnet pool, the same pattern for the same problem
+// (hand out the next free address from a CIDR, track what's in use).
+package eip
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Allocation records one elastic IP handed out to an endpoint.
+type Allocation struct {
+	NetworkID  string
+	EndpointID string
+	ExternalIP string // the elastic IP itself, without prefix
+	CIDR       string // the pool ExternalIP was allocated from
+}
+
+// Manager allocates elastic IPs out of a per-network external pool and
+// keeps an in-memory record of what it has handed out, so Recover can
+// restore allocations across a plugin restart without re-picking an
+// address a live endpoint already holds.
+type Manager struct {
+	logger *logrus.Logger
+
+	mu          sync.Mutex
+	allocations map[string]*Allocation     // keyed by networkID:endpointID
+	used        map[string]map[string]bool // networkID -> set of allocated external IPs
+}
+
+// NewManager creates an elastic IP manager.
+func NewManager(logger *logrus.Logger) *Manager {
+	return &Manager{
+		logger:      logger,
+		allocations: make(map[string]*Allocation),
+		used:        make(map[string]map[string]bool),
+	}
+}
+
+func key(networkID, endpointID string) string {
+	return fmt.Sprintf("%s:%s", networkID, endpointID)
+}
+
+// Allocate reserves the next free address in cidr for the given endpoint
+// and returns it without a prefix (e.g. "203.0.113.5").
+func (m *Manager) Allocate(networkID, endpointID, cidr string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if alloc, ok := m.allocations[key(networkID, endpointID)]; ok {
+		return alloc.ExternalIP, nil
+	}
+
+	ip, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid eip pool %s: %w", cidr, err)
+	}
+
+	used := m.used[networkID]
+	if used == nil {
+		used = make(map[string]bool)
+		m.used[networkID] = used
+	}
+
+	// Walk the pool skipping the network address, the broadcast address, and
+	// .1 (reserved as the pool's own gateway).
+	for candidate := nextIP(ip.Mask(ipnet.Mask)); ipnet.Contains(candidate); candidate = nextIP(candidate) {
+		addr := candidate.String()
+		if strings.HasSuffix(addr, ".1") || used[addr] || isBroadcast(candidate, ipnet) {
+			continue
+		}
+		used[addr] = true
+		m.allocations[key(networkID, endpointID)] = &Allocation{
+			NetworkID:  networkID,
+			EndpointID: endpointID,
+			ExternalIP: addr,
+			CIDR:       cidr,
+		}
+		return addr, nil
+	}
+
+	return "", fmt.Errorf("eip pool %s exhausted for network %s", cidr, networkID)
+}
+
+// Release frees a previously allocated elastic IP.
+func (m *Manager) Release(networkID, endpointID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	alloc, ok := m.allocations[key(networkID, endpointID)]
+	if !ok {
+		return
+	}
+	delete(m.allocations, key(networkID, endpointID))
+	if used := m.used[networkID]; used != nil {
+		delete(used, alloc.ExternalIP)
+	}
+}
+
+// Reserve records an already-allocated elastic IP (e.g. one loaded from the
+// store during Recover) without handing out a new one, so subsequent
+// Allocate calls don't collide with it.
+func (m *Manager) Reserve(networkID, endpointID, externalIP, cidr string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	used := m.used[networkID]
+	if used == nil {
+		used = make(map[string]bool)
+		m.used[networkID] = used
+	}
+	used[externalIP] = true
+	m.allocations[key(networkID, endpointID)] = &Allocation{
+		NetworkID:  networkID,
+		EndpointID: endpointID,
+		ExternalIP: externalIP,
+		CIDR:       cidr,
+	}
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+func isBroadcast(ip net.IP, ipnet *net.IPNet) bool {
+	broadcast := make(net.IP, len(ipnet.IP))
+	for i := range ipnet.IP {
+		broadcast[i] = ipnet.IP[i] | ^ipnet.Mask[i]
+	}
+	return ip.Equal(broadcast)
+}
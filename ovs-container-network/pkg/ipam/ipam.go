@@ -0,0 +1,177 @@
+// Package ipam manages the real IP address management connectToTransitNetwork
+// needs: a stable host address and deterministic MAC for each VPC router's
+// port onto the shared transit network, together with the VPC subnet(s)
+// reachable through that router, so the gateway router's static routes no
+// longer depend on a hardcoded per-router string match.
+package ipam
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TransitAllocation records one VPC router's stable address, MAC, and
+// routed subnets on a transit network.
+type TransitAllocation struct {
+	TransitNetworkID string
+	Router           string
+	IP               string // with prefix, e.g. "192.168.100.10/24"
+	MAC              string
+	Subnets          []string // VPC subnets reachable via Router
+}
+
+// TransitAllocator hands out a stable transit-network address and MAC to
+// each VPC router connecting to it, and tracks the VPC subnets each one
+// routes. It replaces the vpc-a/vpc-b string-match scheme
+// connectToTransitNetwork used to hardcode.
+type TransitAllocator struct {
+	logger *logrus.Logger
+
+	mu          sync.Mutex
+	allocations map[string]*TransitAllocation // keyed by transitNetworkID:router
+	used        map[string]map[string]bool    // transitNetworkID -> set of allocated IPs (no prefix)
+}
+
+// NewTransitAllocator creates a transit network port allocator.
+func NewTransitAllocator(logger *logrus.Logger) *TransitAllocator {
+	return &TransitAllocator{
+		logger:      logger,
+		allocations: make(map[string]*TransitAllocation),
+		used:        make(map[string]map[string]bool),
+	}
+}
+
+func key(transitNetworkID, router string) string {
+	return fmt.Sprintf("%s:%s", transitNetworkID, router)
+}
+
+// Allocate returns router's stable address and MAC on the transit network
+// transitNetworkID/cidr, recording subnets as the VPC subnets reachable
+// through it. Allocate holds the allocator's lock for its entire body, so
+// two routers racing to connect to the same transit network concurrently
+// can't be handed the same address; if router already has an allocation on
+// this transit network, the existing one is returned unchanged instead of
+// minting a second address.
+func (a *TransitAllocator) Allocate(transitNetworkID, cidr, router string, subnets []string) (*TransitAllocation, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if alloc, ok := a.allocations[key(transitNetworkID, router)]; ok {
+		return alloc, nil
+	}
+
+	ip, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transit network cidr %s: %w", cidr, err)
+	}
+	prefixLen, _ := ipnet.Mask.Size()
+
+	used := a.used[transitNetworkID]
+	if used == nil {
+		used = make(map[string]bool)
+		a.used[transitNetworkID] = used
+	}
+
+	// Walk the pool skipping the network address, the broadcast address, and
+	// the gateway router's own port (network+1) - not any address merely
+	// ending in ".1", which over-reserves for anything wider than a /24
+	// (e.g. a /16 would skip every x.x.x.1 in the range).
+	gateway := nextIP(ip.Mask(ipnet.Mask))
+	for candidate := gateway; ipnet.Contains(candidate); candidate = nextIP(candidate) {
+		addr := candidate.String()
+		if candidate.Equal(gateway) || used[addr] || isBroadcast(candidate, ipnet) {
+			continue
+		}
+		used[addr] = true
+		alloc := &TransitAllocation{
+			TransitNetworkID: transitNetworkID,
+			Router:           router,
+			IP:               fmt.Sprintf("%s/%d", addr, prefixLen),
+			MAC:              deterministicMAC(router),
+			Subnets:          subnets,
+		}
+		a.allocations[key(transitNetworkID, router)] = alloc
+		return alloc, nil
+	}
+
+	return nil, fmt.Errorf("transit network pool %s exhausted", cidr)
+}
+
+// Release frees router's allocation on transitNetworkID, e.g. when the last
+// network using it is deleted.
+func (a *TransitAllocator) Release(transitNetworkID, router string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	alloc, ok := a.allocations[key(transitNetworkID, router)]
+	if !ok {
+		return
+	}
+	delete(a.allocations, key(transitNetworkID, router))
+	if used := a.used[transitNetworkID]; used != nil {
+		delete(used, strings.Split(alloc.IP, "/")[0])
+	}
+}
+
+// Reserve records an already-allocated transit port (e.g. one loaded from
+// the store during Recover) without handing out a new one, so subsequent
+// Allocate calls don't collide with it.
+func (a *TransitAllocator) Reserve(alloc *TransitAllocation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	used := a.used[alloc.TransitNetworkID]
+	if used == nil {
+		used = make(map[string]bool)
+		a.used[alloc.TransitNetworkID] = used
+	}
+	used[strings.Split(alloc.IP, "/")[0]] = true
+	a.allocations[key(alloc.TransitNetworkID, alloc.Router)] = alloc
+}
+
+// Lookup returns router's current allocation on transitNetworkID, if any -
+// the read API the Endpoint lifecycle consults to find which VPC subnets
+// route through a given router without recomputing them.
+func (a *TransitAllocator) Lookup(transitNetworkID, router string) (*TransitAllocation, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	alloc, ok := a.allocations[key(transitNetworkID, router)]
+	return alloc, ok
+}
+
+// deterministicMAC derives a stable locally-administered MAC from a hash of
+// seed, matching generateMAC's local-bit-set/multicast-bit-cleared format
+// but deterministic rather than random, so the same router always gets the
+// same transit port MAC across a plugin restart without needing to persist
+// it separately.
+func deterministicMAC(seed string) string {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	sum := h.Sum64()
+	return fmt.Sprintf("02:00:%02x:%02x:%02x:%02x", byte(sum>>24), byte(sum>>16), byte(sum>>8), byte(sum))
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+func isBroadcast(ip net.IP, ipnet *net.IPNet) bool {
+	broadcast := make(net.IP, len(ipnet.IP))
+	for i := range ipnet.IP {
+		broadcast[i] = ipnet.IP[i] | ^ipnet.Mask[i]
+	}
+	return ip.Equal(broadcast)
+}
@@ -0,0 +1,124 @@
+// Package chassis wires OVN's HA_Chassis_Group mechanism - a named, ranked
+// set of chassis a Logical_Switch_Port can fail over across - on top of
+// pkg/ovn's Client primitives. Where Gateway_Chassis ranks failover directly
+// on a Logical_Router_Port (see ovn.Client.SetGatewayChassis, used by the
+// distributed/centralized gateway router port helpers in pkg/driver),
+// HA_Chassis_Group is a standalone object a switch port references by name,
+// for topologies with no dedicated gateway router port to hang
+// gateway-chassis off of.
+package chassis
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+)
+
+// Member is one chassis entry in a network's "ovn.ha_chassis_group.members"
+// option, paired with the failover priority to rank it with inside its
+// HA_Chassis_Group.
+type Member struct {
+	Chassis  string
+	Priority int
+}
+
+// DefaultPriority is the priority a bare chassis ID (no ":priority" suffix)
+// gets in "ovn.ha_chassis_group.members".
+const DefaultPriority = 100
+
+// ParseMembers parses the comma-separated "ovn.ha_chassis_group.members"
+// option, e.g. "chassis-a:100,chassis-b:90". Each entry is either a bare
+// chassis ID (defaulting to DefaultPriority) or a "chassis:priority" pair -
+// the same colon-tuple convention the driver package's
+// "ovn.external_gateway_nodes" option uses for chassis:ip pairs. An empty
+// option parses to no members rather than an error.
+func ParseMembers(raw string) ([]Member, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var members []Member
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		m := Member{Chassis: parts[0], Priority: DefaultPriority}
+		if len(parts) == 2 {
+			p, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid priority %q for chassis %s: %w", parts[1], parts[0], err)
+			}
+			m.Priority = p
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+// EnsureGroup creates the HA_Chassis_Group named name if it doesn't already
+// exist, and adds every member to it, ranked by its Priority. Existing
+// members not present in members are left alone rather than removed, since
+// callers only ever add to a group here - ovn.Client.DeleteHAChassis or
+// DeleteHAChassisGroup is how a group's membership actually shrinks.
+func EnsureGroup(client *ovn.Client, name string, members []Member) error {
+	if err := client.CreateHAChassisGroup(name); err != nil {
+		return fmt.Errorf("failed to create HA chassis group %s: %w", name, err)
+	}
+	for _, m := range members {
+		if err := client.AddHAChassis(name, m.Chassis, m.Priority); err != nil {
+			return fmt.Errorf("failed to add chassis %s to HA chassis group %s: %w", m.Chassis, name, err)
+		}
+	}
+	return nil
+}
+
+// AttachToPort points logical switch port logicalPort at HA_Chassis_Group
+// name, so its egress failover is ranked by that group's members instead of
+// a single static chassis binding.
+func AttachToPort(client *ovn.Client, name, logicalPort string) error {
+	if err := client.SetPortHAChassisGroup(logicalPort, name); err != nil {
+		return fmt.Errorf("failed to attach HA chassis group %s to port %s: %w", name, logicalPort, err)
+	}
+	return nil
+}
+
+// DetachFromPort clears whatever HA_Chassis_Group logicalPort was attached
+// to, leaving it with no distributed failover grouping.
+func DetachFromPort(client *ovn.Client, logicalPort string) error {
+	if err := client.ClearPortHAChassisGroup(logicalPort); err != nil {
+		return fmt.Errorf("failed to detach HA chassis group from port %s: %w", logicalPort, err)
+	}
+	return nil
+}
+
+// LocalChassisID returns this host's OVN chassis ID, read from the local
+// Open_vSwitch database's "external_ids:system-id" - the same identifier
+// ovn-controller registers itself under in the Southbound Chassis table.
+// Returns "" if ovs-vsctl can't be reached.
+func LocalChassisID() string {
+	output, err := exec.Command("ovs-vsctl", "get", "open_vswitch", ".", "external_ids:system-id").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(string(output)), "\"")
+}
+
+// EnsureRegistered waits for chassisID to appear in the Southbound Chassis
+// table, via ovn.Client.WaitForChassis. It deliberately doesn't fabricate a
+// Chassis row itself if one isn't there: that row is owned exclusively by a
+// live ovn-controller process on the chassis in question, and a synthetic
+// row written by the plugin would be both incorrect (missing encap/bridge
+// state only ovn-controller knows) and reaped the next time ovn-controller
+// reconciles the table. Surfacing "not registered yet" as an error is more
+// honest than pretending registration is this plugin's job.
+func EnsureRegistered(client *ovn.Client, chassisID string, timeout time.Duration) error {
+	if err := client.WaitForChassis(chassisID, timeout); err != nil {
+		return fmt.Errorf("chassis %s not registered in Southbound database (expected ovn-controller to register it): %w", chassisID, err)
+	}
+	return nil
+}
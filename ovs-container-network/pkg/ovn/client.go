@@ -3,19 +3,35 @@ package ovn
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"os/exec"
 	"strings"
 	"time"
 
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovs/ovsdb"
 	"github.com/sirupsen/logrus"
 )
 
-// Client provides an interface to OVN (Open Virtual Network) using ovn-nbctl
+const (
+	nbDatabase = "OVN_Northbound"
+	sbDatabase = "OVN_Southbound"
+)
+
+// Client provides an interface to OVN (Open Virtual Network). Most
+// operations still shell out to ovn-nbctl, one process per call; nb/sb hold
+// a native OVSDB connection (see Begin, Monitor) used for operations that
+// need atomicity across several mutations or live updates, which ovn-nbctl
+// can't offer. Native connection failures are non-fatal: the client falls
+// back to ovn-nbctl-only operation and logs a warning.
 type Client struct {
 	logger       *logrus.Logger
-	nbConnection string // TCP address for OVN Northbound DB
-	sbConnection string // TCP address for OVN Southbound DB
+	nbConnection string // TCP/SSL address for OVN Northbound DB
+	sbConnection string // TCP/SSL address for OVN Southbound DB
+
+	nb *ovsdb.Client // native connection to nbConnection, nil if unavailable
+	sb *ovsdb.Client // native connection to sbConnection, nil if unavailable
 }
 
 // NewClient creates a new OVN client using ovn-nbctl commands
@@ -47,9 +63,53 @@ func NewClient(nbConn, sbConn string) (*Client, error) {
 	}
 
 	c.logger.Infof("Connected to OVN northbound at %s", nbConn)
+
+	if nb, err := dialNative(nbConn); err != nil {
+		c.logger.WithError(err).Warn("Native OVSDB connection to Northbound unavailable, falling back to ovn-nbctl for transactions")
+	} else {
+		c.nb = nb
+	}
+	if sb, err := dialNative(sbConn); err != nil {
+		c.logger.WithError(err).Warn("Native OVSDB connection to Southbound unavailable, Monitor will be unavailable")
+	} else {
+		c.sb = sb
+	}
+
 	return c, nil
 }
 
+// dialNative opens a native OVSDB connection to an ovn-nbctl style
+// connection string ("tcp:host:port" or "ssl:host:port").
+func dialNative(conn string) (*ovsdb.Client, error) {
+	addr, tlsConfig, err := parseOVNConnection(conn)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		return ovsdb.DialTLS(addr, tlsConfig)
+	}
+	return ovsdb.DialTCP(addr)
+}
+
+// parseOVNConnection splits an ovn-nbctl style connection string into a
+// bare host:port and, for "ssl:", a TLS config. Native callers that need
+// mutual TLS (client certificates) should use ovsdb.DialTLS directly with a
+// fully configured tls.Config instead of going through NewClient.
+func parseOVNConnection(conn string) (string, *tls.Config, error) {
+	parts := strings.SplitN(conn, ":", 2)
+	if len(parts) != 2 {
+		return "", nil, fmt.Errorf("invalid OVN connection string %q", conn)
+	}
+	switch parts[0] {
+	case "tcp":
+		return parts[1], nil, nil
+	case "ssl":
+		return parts[1], &tls.Config{}, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported OVN connection scheme %q", parts[0])
+	}
+}
+
 // execNBCtl executes an ovn-nbctl command with the remote connection
 func (c *Client) execNBCtl(args ...string) (string, error) {
 	// Prepend the database connection
@@ -69,6 +129,25 @@ func (c *Client) execNBCtl(args ...string) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// execSBCtl executes an ovn-sbctl command with the remote connection
+func (c *Client) execSBCtl(args ...string) (string, error) {
+	// Prepend the database connection
+	cmdArgs := append([]string{"--db=" + c.sbConnection}, args...)
+
+	cmd := exec.Command("ovn-sbctl", cmdArgs...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	c.logger.Debugf("Executing: ovn-sbctl %s", strings.Join(cmdArgs, " "))
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ovn-sbctl failed: %w, stderr: %s", err, stderr.String())
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
 // Connect establishes connection to OVN (compatibility method, connection is tested in NewClient)
 func (c *Client) Connect(ctx context.Context) error {
 	// Connection is already established and tested in NewClient
@@ -78,9 +157,280 @@ func (c *Client) Connect(ctx context.Context) error {
 // Disconnect closes the connection to OVN (compatibility method, no persistent connection with exec)
 func (c *Client) Disconnect() {
 	// No persistent connection to close when using exec
+	if c.nb != nil {
+		c.nb.Close()
+	}
+	if c.sb != nil {
+		c.sb.Close()
+	}
 	c.logger.Info("Disconnected from OVN")
 }
 
+// Txn batches a sequence of logical-topology mutations into a single
+// atomic OVSDB transaction against the Northbound database, so e.g.
+// creating a logical port and setting its addresses and port security
+// can't be observed half-applied the way issuing lsp-add, lsp-set-
+// addresses, and lsp-set-port-security as three separate ovn-nbctl calls
+// can.
+type Txn struct {
+	client *Client
+	ops    []ovsdb.Op
+}
+
+// Begin starts a new atomic transaction against the Northbound database.
+// It requires the native OVSDB connection established by NewClient; if
+// that connection isn't available, callers should fall back to the
+// ovn-nbctl-based methods.
+func (c *Client) Begin() (*Txn, error) {
+	if c.nb == nil {
+		return nil, fmt.Errorf("ovn: native OVSDB connection to Northbound not available")
+	}
+	return &Txn{client: c}, nil
+}
+
+// AddLogicalPort queues creating a logical switch port on lswitch with the
+// given MAC/IP (set as both addresses and port_security, mirroring
+// CreateLogicalPort's exec-based behavior) and external IDs, plus the
+// Logical_Switch mutation that adds it to the switch's port set - both
+// applied atomically on Commit.
+func (t *Txn) AddLogicalPort(lswitch, portName, macAddress, ipAddress string, externalIDs map[string]string) *Txn {
+	uuidName := "lsp_" + sanitizeUUIDName(portName)
+
+	row := map[string]interface{}{
+		"name": portName,
+	}
+	if macAddress != "" {
+		addr := macAddress
+		if ipAddress != "" {
+			addr = fmt.Sprintf("%s %s", macAddress, ipAddress)
+		}
+		row["addresses"] = ovsdb.Set([]string{addr})
+		row["port_security"] = ovsdb.Set([]string{addr})
+	}
+	if len(externalIDs) > 0 {
+		row["external_ids"] = ovsdb.StringMap(externalIDs)
+	}
+
+	t.ops = append(t.ops,
+		ovsdb.Op{Op: "insert", Table: "Logical_Switch_Port", Row: row, UUIDName: uuidName},
+		ovsdb.Op{
+			Op:    "mutate",
+			Table: "Logical_Switch",
+			Where: []interface{}{[]interface{}{"name", "==", lswitch}},
+			Mutations: []interface{}{
+				[]interface{}{"ports", "insert", []interface{}{"set", []interface{}{ovsdb.NamedUUID(uuidName)}}},
+			},
+		},
+	)
+	return t
+}
+
+// sanitizeUUIDName turns a port name into a valid OVSDB uuid-name: it must
+// start with a letter or underscore and contain only word characters.
+func sanitizeUUIDName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// Monitor subscribes via monitor_cond to Logical_Switch_Port changes on the
+// Northbound database and Port_Binding changes on the Southbound database,
+// merging both into a single channel so the plugin can learn when a port
+// becomes bound on a chassis instead of sleeping or polling for it.
+// Requires both native OVSDB connections established by NewClient.
+func (c *Client) Monitor() (<-chan ovsdb.Update, error) {
+	if c.nb == nil || c.sb == nil {
+		return nil, fmt.Errorf("ovn: native OVSDB connections not available, cannot monitor")
+	}
+
+	if err := c.nb.MonitorCond(nbDatabase, map[string][]ovsdb.Condition{"Logical_Switch_Port": nil}); err != nil {
+		return nil, fmt.Errorf("ovn: failed to monitor Logical_Switch_Port: %w", err)
+	}
+	if err := c.sb.MonitorCond(sbDatabase, map[string][]ovsdb.Condition{"Port_Binding": nil}); err != nil {
+		return nil, fmt.Errorf("ovn: failed to monitor Port_Binding: %w", err)
+	}
+
+	out := make(chan ovsdb.Update, 64)
+	nbUpdates := c.nb.Listen()
+	sbUpdates := c.sb.Listen()
+
+	go func() {
+		defer close(out)
+		for nbUpdates != nil || sbUpdates != nil {
+			select {
+			case u, ok := <-nbUpdates:
+				if !ok {
+					nbUpdates = nil
+					continue
+				}
+				out <- u
+			case u, ok := <-sbUpdates:
+				if !ok {
+					sbUpdates = nil
+					continue
+				}
+				out <- u
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// LogicalSwitchState is what RehydrateLogicalSwitches recovers directly
+// from the Northbound database: just enough to tell whether the on-disk
+// store agrees with what OVN itself still has.
+type LogicalSwitchState struct {
+	Name        string
+	ExternalIDs map[string]string
+	PortNames   []string
+}
+
+// RehydrateLogicalSwitches reads every Logical_Switch and its ports
+// straight from the Northbound database over the native connection, for
+// use when the on-disk store is missing or suspected stale (e.g. its
+// networks.json predates a lost volume) and OVN is the best remaining
+// source of truth. It cannot recover Docker-side network options (those
+// were never stored in OVN), only the logical topology.
+func (c *Client) RehydrateLogicalSwitches() ([]LogicalSwitchState, error) {
+	if c.nb == nil {
+		return nil, fmt.Errorf("ovn: native OVSDB connection to Northbound not available")
+	}
+
+	results, err := c.nb.Transact(nbDatabase, []ovsdb.Op{
+		{Op: "select", Table: "Logical_Switch", Columns: []string{"name", "external_ids", "ports"}, Where: []interface{}{}},
+		{Op: "select", Table: "Logical_Switch_Port", Columns: []string{"_uuid", "name"}, Where: []interface{}{}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ovn: failed to list logical switches: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	portNames := make(map[string]string) // port row uuid -> port name
+	if len(results) > 1 {
+		for _, raw := range results[1].Rows {
+			var row struct {
+				UUID json.RawMessage `json:"_uuid"`
+				Name string          `json:"name"`
+			}
+			if err := json.Unmarshal(raw, &row); err != nil {
+				c.logger.WithError(err).Warn("ovn: failed to decode Logical_Switch_Port row during rehydration")
+				continue
+			}
+			for _, id := range decodeOVSDBUUIDSet(row.UUID) {
+				portNames[id] = row.Name
+			}
+		}
+	}
+
+	var states []LogicalSwitchState
+	for _, raw := range results[0].Rows {
+		var row struct {
+			Name        string          `json:"name"`
+			ExternalIDs json.RawMessage `json:"external_ids"`
+			Ports       json.RawMessage `json:"ports"`
+		}
+		if err := json.Unmarshal(raw, &row); err != nil {
+			c.logger.WithError(err).Warn("ovn: failed to decode Logical_Switch row during rehydration")
+			continue
+		}
+
+		var ports []string
+		for _, id := range decodeOVSDBUUIDSet(row.Ports) {
+			if name, ok := portNames[id]; ok {
+				ports = append(ports, name)
+			}
+		}
+
+		states = append(states, LogicalSwitchState{
+			Name:        row.Name,
+			ExternalIDs: decodeOVSDBMap(row.ExternalIDs),
+			PortNames:   ports,
+		})
+	}
+	return states, nil
+}
+
+// decodeOVSDBUUIDSet decodes the OVSDB wire form of a set-of-uuid column: a
+// single ["uuid", id] pair, or ["set", [["uuid", id], ...]] for more than
+// one.
+func decodeOVSDBUUIDSet(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var pair []json.RawMessage
+	if err := json.Unmarshal(raw, &pair); err != nil || len(pair) != 2 {
+		return nil
+	}
+
+	var tag string
+	if err := json.Unmarshal(pair[0], &tag); err != nil {
+		return nil
+	}
+
+	switch tag {
+	case "uuid":
+		var id string
+		if err := json.Unmarshal(pair[1], &id); err != nil {
+			return nil
+		}
+		return []string{id}
+	case "set":
+		var members []json.RawMessage
+		if err := json.Unmarshal(pair[1], &members); err != nil {
+			return nil
+		}
+		var ids []string
+		for _, m := range members {
+			var mp []json.RawMessage
+			if err := json.Unmarshal(m, &mp); err != nil || len(mp) != 2 {
+				continue
+			}
+			var id string
+			if err := json.Unmarshal(mp[1], &id); err != nil {
+				continue
+			}
+			ids = append(ids, id)
+		}
+		return ids
+	default:
+		return nil
+	}
+}
+
+// decodeOVSDBMap decodes the OVSDB wire form of a map column, either the
+// shorthand {} for an empty map or ["map", [[k, v], ...]] otherwise.
+func decodeOVSDBMap(raw json.RawMessage) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var pair []json.RawMessage
+	if err := json.Unmarshal(raw, &pair); err != nil || len(pair) != 2 {
+		return nil
+	}
+
+	var entries [][2]string
+	if err := json.Unmarshal(pair[1], &entries); err != nil {
+		return nil
+	}
+
+	result := make(map[string]string, len(entries))
+	for _, kv := range entries {
+		result[kv[0]] = kv[1]
+	}
+	return result
+}
+
 // CreateLogicalSwitch creates a logical switch in OVN
 func (c *Client) CreateLogicalSwitch(name string, externalIDs map[string]string) error {
 	// Check if switch already exists
@@ -137,14 +487,19 @@ func (c *Client) CreateLogicalPort(lswitch, portName, macAddress, ipAddress stri
 		c.logger.Infof("Logical port %s already exists, updating", portName)
 	}
 
-	// Check if this is a router-type port
+	// Check if this is a router- or localnet-type port
 	isRouterPort := false
 	if portType, ok := options["type"]; ok && portType == "router" {
 		isRouterPort = true
 	}
+	isLocalnetPort := false
+	if portType, ok := options["type"]; ok && portType == "localnet" {
+		isLocalnetPort = true
+	}
 
-	// Set addresses if provided (skip for router ports, they use "router" keyword)
-	if !isRouterPort && macAddress != "" && ipAddress != "" {
+	// Set addresses if provided (skip for router ports, they use "router"
+	// keyword, and for localnet ports, which use "unknown")
+	if !isRouterPort && !isLocalnetPort && macAddress != "" && ipAddress != "" {
 		address := fmt.Sprintf("%s %s", macAddress, ipAddress)
 		if _, err := c.execNBCtl("lsp-set-addresses", portName, address); err != nil {
 			return fmt.Errorf("failed to set addresses on port %s: %w", portName, err)
@@ -167,11 +522,32 @@ func (c *Client) CreateLogicalPort(lswitch, portName, macAddress, ipAddress stri
 			if _, err := c.execNBCtl("lsp-set-addresses", portName, "router"); err != nil {
 				c.logger.WithError(err).Warnf("Failed to set router addresses on port %s", portName)
 			}
+		} else if key == "type" && value == "localnet" {
+			// Set port type to localnet, pinning it to a physical network
+			// via the network_name option below - the uplink a u2o
+			// interconnection or provider network egresses traffic through.
+			if _, err := c.execNBCtl("lsp-set-type", portName, "localnet"); err != nil {
+				return fmt.Errorf("failed to set port type to localnet: %w", err)
+			}
+			if _, err := c.execNBCtl("lsp-set-addresses", portName, "unknown"); err != nil {
+				c.logger.WithError(err).Warnf("Failed to set localnet addresses on port %s", portName)
+			}
+		} else if key == "network_name" {
+			// Map the localnet port to a physical network, matching the
+			// chassis's own ovn-bridge-mappings external-id.
+			if _, err := c.execNBCtl("lsp-set-options", portName, fmt.Sprintf("network_name=%s", value)); err != nil {
+				return fmt.Errorf("failed to set network_name option: %w", err)
+			}
 		} else if key == "router-port" {
 			// Link to router port
 			if _, err := c.execNBCtl("lsp-set-options", portName, fmt.Sprintf("router-port=%s", value)); err != nil {
 				return fmt.Errorf("failed to set router-port option: %w", err)
 			}
+		} else if key == "tag" {
+			// VLAN-tag a localnet port, e.g. for a VLANAttachment.
+			if _, err := c.execNBCtl("set", "Logical_Switch_Port", portName, fmt.Sprintf("tag=%s", value)); err != nil {
+				return fmt.Errorf("failed to set VLAN tag on port %s: %w", portName, err)
+			}
 		} else if key == "addresses" {
 			// Already handled above, skip
 			continue
@@ -322,6 +698,36 @@ func (c *Client) CreateLogicalRouterPort(router, portName, mac string, networks
 	return nil
 }
 
+// LogicalRouterExists reports whether a Logical_Router named name already
+// exists, for callers that want strict "find, don't create" semantics (see
+// the driver's "ovn.router.auto_create" option) instead of
+// CreateLogicalRouter's idempotent create-or-find.
+func (c *Client) LogicalRouterExists(name string) (bool, error) {
+	output, err := c.execNBCtl("lr-list")
+	if err != nil {
+		return false, fmt.Errorf("failed to list routers: %w", err)
+	}
+	return strings.Contains(output, name), nil
+}
+
+// CreateLogicalRouterPeerPort creates a logical router port, peering it directly
+// to peerPort on another logical router (no switch involved) so two routers
+// can exchange routes across the link - used for a statically declared
+// "ovn.router.peer" connection rather than the dynamic VPC transit network's
+// shared switch.
+func (c *Client) CreateLogicalRouterPeerPort(router, portName, mac string, networks []string, peerPort string) error {
+	networkStr := strings.Join(networks, " ")
+	if _, err := c.execNBCtl("lrp-add", router, portName, mac, networkStr, fmt.Sprintf("peer=%s", peerPort)); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("failed to create logical router peer port %s: %w", portName, err)
+		}
+		c.logger.Infof("Logical router port %s already exists", portName)
+	}
+
+	c.logger.Infof("Created logical router peer port %s on router %s, peered to %s", portName, router, peerPort)
+	return nil
+}
+
 // DeleteLogicalRouterPort deletes a logical router port
 func (c *Client) DeleteLogicalRouterPort(portName string) error {
 	if _, err := c.execNBCtl("lrp-del", portName); err != nil {
@@ -337,6 +743,68 @@ func (c *Client) DeleteLogicalRouterPort(portName string) error {
 	return nil
 }
 
+// PortExists reports whether a logical router port named portName currently
+// exists, for callers (e.g. gateway-mode migration) that need to check
+// before tearing one down or recreating it.
+func (c *Client) PortExists(portName string) bool {
+	_, err := c.execNBCtl("get", "Logical_Router_Port", portName, "name")
+	return err == nil
+}
+
+// ChassisUUID returns the Southbound Chassis row's UUID for the chassis
+// named chassisID, or "" if ovn-controller hasn't registered it there yet.
+func (c *Client) ChassisUUID(chassisID string) (string, error) {
+	output, err := c.execSBCtl("--bare", "--columns=_uuid", "find", "Chassis", fmt.Sprintf("name=%s", chassisID))
+	if err != nil {
+		return "", fmt.Errorf("failed to query chassis %s: %w", chassisID, err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// WaitForChassis polls the Southbound Chassis table until chassisID is
+// registered or timeout elapses, mirroring the subnet-ready poll pattern
+// kube-ovn controllers use - the plugin shouldn't start binding ports on a
+// chassis ovn-controller hasn't connected and announced yet.
+func (c *Client) WaitForChassis(chassisID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if uuid, err := c.ChassisUUID(chassisID); err == nil && uuid != "" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for chassis %s to register in the Southbound database", timeout, chassisID)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// PortBindingChassis returns the Southbound Chassis UUID that logical port
+// portName is currently bound to, or "" if it isn't bound yet.
+func (c *Client) PortBindingChassis(portName string) (string, error) {
+	output, err := c.execSBCtl("--bare", "--columns=chassis", "find", "Port_Binding", fmt.Sprintf("logical_port=%s", portName))
+	if err != nil {
+		return "", fmt.Errorf("failed to query port binding for %s: %w", portName, err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// WaitForPortBinding polls the Southbound Port_Binding table until portName
+// is bound to chassisUUID or timeout elapses, so a caller can confirm
+// ovn-controller actually bound the port instead of assuming it eventually
+// will.
+func (c *Client) WaitForPortBinding(portName, chassisUUID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if bound, err := c.PortBindingChassis(portName); err == nil && bound == chassisUUID {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for port %s to bind to chassis %s", timeout, portName, chassisUUID)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
 // AddStaticRoute adds a static route to a logical router
 func (c *Client) AddStaticRoute(router, prefix, nexthop string) error {
 	if _, err := c.execNBCtl("lr-route-add", router, prefix, nexthop); err != nil {
@@ -367,35 +835,263 @@ func (c *Client) DeleteStaticRoute(router, prefix string) error {
 	return nil
 }
 
-// CreateDHCPOptions creates DHCP options for a subnet
-func (c *Client) CreateDHCPOptions(cidr, serverMAC, serverIP string, options map[string]string) (string, error) {
-	// Create DHCP options
-	args := []string{"dhcp-options-create", cidr}
+// AddSNAT adds a source-NAT rule translating traffic leaving the router from
+// subnet to externalIP, used to NAT per-tenant east-west traffic crossing a
+// distributed router so it doesn't leak a tenant's internal addressing to
+// switches it isn't supposed to reach.
+func (c *Client) AddSNAT(router, subnet, externalIP string) error {
+	if _, err := c.execNBCtl("lr-nat-add", router, "snat", externalIP, subnet); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("failed to add SNAT on router %s: %w", router, err)
+		}
+		c.logger.Infof("SNAT %s -> %s already exists on router %s", subnet, externalIP, router)
+	}
 
-	output, err := c.execNBCtl(args...)
-	if err != nil {
-		return "", fmt.Errorf("failed to create DHCP options: %w", err)
+	c.logger.Infof("Added SNAT %s -> %s on router %s", subnet, externalIP, router)
+	return nil
+}
+
+// DeleteSNAT removes a source-NAT rule for subnet from a logical router
+func (c *Client) DeleteSNAT(router, subnet string) error {
+	if _, err := c.execNBCtl("lr-nat-del", router, "snat", subnet); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			c.logger.Infof("SNAT for %s doesn't exist on router %s", subnet, router)
+			return nil
+		}
+		return fmt.Errorf("failed to delete SNAT on router %s: %w", router, err)
 	}
 
-	// Output contains the UUID of the created DHCP options
-	dhcpUUID := strings.TrimSpace(output)
+	c.logger.Infof("Deleted SNAT for %s from router %s", subnet, router)
+	return nil
+}
+
+// AddDNATAndSNAT binds externalIP to internalIP on router, translating both
+// directions: inbound traffic to externalIP is DNATed to internalIP, and
+// internalIP's egress is SNATed to externalIP. This is how a floating/
+// elastic IP gives one endpoint a persistent public address.
+func (c *Client) AddDNATAndSNAT(router, externalIP, internalIP string) error {
+	if _, err := c.execNBCtl("lr-nat-add", router, "dnat_and_snat", externalIP, internalIP); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("failed to add DNAT_and_SNAT on router %s: %w", router, err)
+		}
+		c.logger.Infof("DNAT_and_SNAT %s -> %s already exists on router %s", externalIP, internalIP, router)
+	}
+
+	c.logger.Infof("Added DNAT_and_SNAT %s -> %s on router %s", externalIP, internalIP, router)
+	return nil
+}
+
+// DeleteDNATAndSNAT removes a DNAT_and_SNAT rule for externalIP from router.
+func (c *Client) DeleteDNATAndSNAT(router, externalIP string) error {
+	if _, err := c.execNBCtl("lr-nat-del", router, "dnat_and_snat", externalIP); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			c.logger.Infof("DNAT_and_SNAT for %s doesn't exist on router %s", externalIP, router)
+			return nil
+		}
+		return fmt.Errorf("failed to delete DNAT_and_SNAT on router %s: %w", router, err)
+	}
+
+	c.logger.Infof("Deleted DNAT_and_SNAT for %s from router %s", externalIP, router)
+	return nil
+}
+
+// AddLogicalRouterPolicy installs a routing policy on router: traffic
+// matching match is rerouted to nexthop when action is "reroute", or simply
+// allowed/dropped (nexthop ignored) for "allow"/"drop". Priority breaks ties
+// the same way OVN itself does - higher wins.
+func (c *Client) AddLogicalRouterPolicy(router string, priority int, match, action, nexthop string) error {
+	args := []string{"lr-policy-add", router, fmt.Sprintf("%d", priority), match, action}
+	if nexthop != "" {
+		args = append(args, nexthop)
+	}
+	if _, err := c.execNBCtl(args...); err != nil {
+		return fmt.Errorf("failed to add logical router policy on %s: %w", router, err)
+	}
+
+	c.logger.Infof("Added logical router policy on %s: priority=%d match=%q action=%s", router, priority, match, action)
+	return nil
+}
+
+// DeleteLogicalRouterPolicy removes the policy on router identified by
+// priority and match - ovn-nbctl needs both to pick out a single policy row.
+func (c *Client) DeleteLogicalRouterPolicy(router string, priority int, match string) error {
+	if _, err := c.execNBCtl("lr-policy-del", router, fmt.Sprintf("%d", priority), match); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			c.logger.Infof("Logical router policy on %s (priority=%d match=%q) doesn't exist", router, priority, match)
+			return nil
+		}
+		return fmt.Errorf("failed to delete logical router policy on %s: %w", router, err)
+	}
+
+	c.logger.Infof("Deleted logical router policy on %s: priority=%d match=%q", router, priority, match)
+	return nil
+}
+
+// SetGatewayChassis pins portName's gateway onto chassis. A port can list
+// more than one, ranked by priority - the highest-priority chassis is
+// active, the rest are failover standbys.
+func (c *Client) SetGatewayChassis(portName, chassis string, priority int) error {
+	if _, err := c.execNBCtl("lrp-set-gateway-chassis", portName, chassis, fmt.Sprintf("%d", priority)); err != nil {
+		return fmt.Errorf("failed to set gateway chassis %s on port %s: %w", chassis, portName, err)
+	}
+
+	c.logger.Infof("Set gateway chassis %s (priority %d) on port %s", chassis, priority, portName)
+	return nil
+}
+
+// DeleteGatewayChassis removes chassis from portName's gateway chassis list.
+func (c *Client) DeleteGatewayChassis(portName, chassis string) error {
+	if _, err := c.execNBCtl("lrp-del-gateway-chassis", portName, chassis); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			c.logger.Infof("Gateway chassis %s on port %s doesn't exist", chassis, portName)
+			return nil
+		}
+		return fmt.Errorf("failed to delete gateway chassis %s from port %s: %w", chassis, portName, err)
+	}
+
+	c.logger.Infof("Deleted gateway chassis %s from port %s", chassis, portName)
+	return nil
+}
+
+// CreateBFD enables BFD monitoring from port to dstIP, so a gateway chassis
+// failover (driven by SetGatewayChassis priority) is detected as fast as the
+// link allows instead of waiting on OVN's default liveness timers.
+func (c *Client) CreateBFD(port, dstIP string) error {
+	if _, err := c.execNBCtl("bfd-add", port, dstIP); err != nil {
+		return fmt.Errorf("failed to add BFD session on %s to %s: %w", port, dstIP, err)
+	}
+
+	c.logger.Infof("Added BFD session on %s to %s", port, dstIP)
+	return nil
+}
+
+// CreateHAChassisGroup creates an HA_Chassis_Group named name, idempotently.
+// Unlike Gateway_Chassis (ranked directly on a Logical_Router_Port by
+// SetGatewayChassis), HA_Chassis_Group is a standalone, named set of ranked
+// chassis that a Logical_Switch_Port references by name via
+// SetPortHAChassisGroup - the mechanism OVN uses to fail over a distributed
+// gateway port's egress chassis without a router port in the picture at all.
+func (c *Client) CreateHAChassisGroup(name string) error {
+	if _, err := c.execNBCtl("ha-chassis-group-add", name); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("failed to create HA chassis group %s: %w", name, err)
+		}
+		c.logger.Infof("HA chassis group %s already exists", name)
+	}
+
+	c.logger.Infof("Created HA chassis group %s", name)
+	return nil
+}
+
+// DeleteHAChassisGroup removes an HA_Chassis_Group, and with it every
+// HA_Chassis row it owns.
+func (c *Client) DeleteHAChassisGroup(name string) error {
+	if _, err := c.execNBCtl("ha-chassis-group-del", name); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			c.logger.Infof("HA chassis group %s doesn't exist", name)
+			return nil
+		}
+		return fmt.Errorf("failed to delete HA chassis group %s: %w", name, err)
+	}
+
+	c.logger.Infof("Deleted HA chassis group %s", name)
+	return nil
+}
+
+// AddHAChassis adds chassis to group with the given priority, ranking it
+// against the group's other members - same priority semantics as
+// SetGatewayChassis, highest wins.
+func (c *Client) AddHAChassis(group, chassis string, priority int) error {
+	if _, err := c.execNBCtl("ha-chassis-group-add-chassis", group, chassis, fmt.Sprintf("%d", priority)); err != nil {
+		return fmt.Errorf("failed to add chassis %s to HA chassis group %s: %w", chassis, group, err)
+	}
+
+	c.logger.Infof("Added chassis %s (priority %d) to HA chassis group %s", chassis, priority, group)
+	return nil
+}
+
+// DeleteHAChassis removes chassis from group.
+func (c *Client) DeleteHAChassis(group, chassis string) error {
+	if _, err := c.execNBCtl("ha-chassis-group-remove-chassis", group, chassis); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			c.logger.Infof("Chassis %s isn't a member of HA chassis group %s", chassis, group)
+			return nil
+		}
+		return fmt.Errorf("failed to remove chassis %s from HA chassis group %s: %w", chassis, group, err)
+	}
+
+	c.logger.Infof("Removed chassis %s from HA chassis group %s", chassis, group)
+	return nil
+}
+
+// SetPortHAChassisGroup points logical switch port portName at group, so its
+// egress failover is ranked by group's HA_Chassis members instead of the
+// port's single static chassis binding.
+func (c *Client) SetPortHAChassisGroup(portName, group string) error {
+	if _, err := c.execNBCtl("lsp-set-ha-chassis-group", portName, group); err != nil {
+		return fmt.Errorf("failed to set HA chassis group %s on port %s: %w", group, portName, err)
+	}
+
+	c.logger.Infof("Set HA chassis group %s on port %s", group, portName)
+	return nil
+}
+
+// ClearPortHAChassisGroup detaches portName from whatever HA_Chassis_Group it
+// was pointed at, leaving it with no distributed failover grouping.
+func (c *Client) ClearPortHAChassisGroup(portName string) error {
+	if _, err := c.execNBCtl("lsp-set-ha-chassis-group", portName); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			c.logger.Infof("Port %s has no HA chassis group to clear", portName)
+			return nil
+		}
+		return fmt.Errorf("failed to clear HA chassis group on port %s: %w", portName, err)
+	}
+
+	c.logger.Infof("Cleared HA chassis group on port %s", portName)
+	return nil
+}
 
-	// Set DHCP options
-	setArgs := []string{"dhcp-options-set-options", dhcpUUID,
+// dhcpv4SetArgs builds the ovn-nbctl dhcp-options-set-options argument list
+// for dhcpUUID, shared by CreateDHCPOptions (a freshly created row) and
+// UpdateDHCPOptions (an existing one) so a network's options are applied
+// identically whether set at creation or rewritten on change.
+func dhcpv4SetArgs(dhcpUUID, serverMAC, serverIP string, options map[string]string) []string {
+	args := []string{"dhcp-options-set-options", dhcpUUID,
 		fmt.Sprintf("server_id=%s", serverIP),
 		fmt.Sprintf("server_mac=%s", serverMAC),
 		fmt.Sprintf("lease_time=%s", options["lease_time"]),
 	}
 
 	if router, ok := options["router"]; ok {
-		setArgs = append(setArgs, fmt.Sprintf("router=%s", router))
+		args = append(args, fmt.Sprintf("router=%s", router))
 	}
-
 	if dns, ok := options["dns_server"]; ok {
-		setArgs = append(setArgs, fmt.Sprintf("dns_server=%s", dns))
+		args = append(args, fmt.Sprintf("dns_server=%s", dns))
+	}
+	if mtu, ok := options["mtu"]; ok {
+		args = append(args, fmt.Sprintf("mtu=%s", mtu))
+	}
+	if domain, ok := options["domain"]; ok {
+		args = append(args, fmt.Sprintf("domain_name=%s", domain))
+	}
+
+	return args
+}
+
+// CreateDHCPOptions creates DHCP options for a subnet
+func (c *Client) CreateDHCPOptions(cidr, serverMAC, serverIP string, options map[string]string) (string, error) {
+	// Create DHCP options
+	args := []string{"dhcp-options-create", cidr}
+
+	output, err := c.execNBCtl(args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create DHCP options: %w", err)
 	}
 
-	if _, err := c.execNBCtl(setArgs...); err != nil {
+	// Output contains the UUID of the created DHCP options
+	dhcpUUID := strings.TrimSpace(output)
+
+	if _, err := c.execNBCtl(dhcpv4SetArgs(dhcpUUID, serverMAC, serverIP, options)...); err != nil {
 		// Try to clean up the created DHCP options
 		c.execNBCtl("dhcp-options-del", dhcpUUID)
 		return "", fmt.Errorf("failed to set DHCP options: %w", err)
@@ -405,6 +1101,22 @@ func (c *Client) CreateDHCPOptions(cidr, serverMAC, serverIP string, options map
 	return dhcpUUID, nil
 }
 
+// UpdateDHCPOptions rewrites an existing DHCP_Options row's option set in
+// place, so a Driver reconciling a network whose ovn.dhcp4.* options
+// changed can pick up the new values without tearing down and recreating
+// the row - which would orphan it until every port referencing dhcpUUID
+// got re-pointed. dhcp-options-set-options replaces the whole option map
+// per call, so this issues the same command CreateDHCPOptions does, just
+// against an existing UUID instead of a freshly created one.
+func (c *Client) UpdateDHCPOptions(dhcpUUID, serverMAC, serverIP string, options map[string]string) error {
+	if _, err := c.execNBCtl(dhcpv4SetArgs(dhcpUUID, serverMAC, serverIP, options)...); err != nil {
+		return fmt.Errorf("failed to update DHCP options %s: %w", dhcpUUID, err)
+	}
+
+	c.logger.Infof("Updated DHCP options %s", dhcpUUID)
+	return nil
+}
+
 // SetPortDHCP configures a port to use DHCP options
 func (c *Client) SetPortDHCP(portName, dhcpOptionsUUID string) error {
 	// Set DHCP options UUID on the port
@@ -416,6 +1128,177 @@ func (c *Client) SetPortDHCP(portName, dhcpOptionsUUID string) error {
 	return nil
 }
 
+// dhcpv6SetArgs builds the ovn-nbctl dhcp-options-set-options argument list
+// for dhcpUUID, shared by CreateDHCPv6Options and UpdateDHCPv6Options -
+// mirroring dhcpv4SetArgs.
+func dhcpv6SetArgs(dhcpUUID, serverID string, options map[string]string) []string {
+	args := []string{"dhcp-options-set-options", dhcpUUID,
+		fmt.Sprintf("server_id=%s", serverID),
+	}
+	if dns, ok := options["dns_server"]; ok {
+		args = append(args, fmt.Sprintf("dns_server=%s", dns))
+	}
+	if domainSearch, ok := options["domain_search"]; ok {
+		args = append(args, fmt.Sprintf("domain_search=%s", domainSearch))
+	}
+	return args
+}
+
+// CreateDHCPv6Options creates an OVN DHCP_Options row for prefix (e.g.
+// "2001:db8:1::/64"), RFC 8415-style: server_id (conventionally the
+// router's own link-local or global address, used as the DHCPv6 server's
+// DUID), dns_server, and an optional domain_search.
+func (c *Client) CreateDHCPv6Options(prefix, serverID string, options map[string]string) (string, error) {
+	output, err := c.execNBCtl("dhcpv6-options-create", prefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to create DHCPv6 options: %w", err)
+	}
+	dhcpUUID := strings.TrimSpace(output)
+
+	if _, err := c.execNBCtl(dhcpv6SetArgs(dhcpUUID, serverID, options)...); err != nil {
+		// Try to clean up the created DHCP options
+		c.execNBCtl("dhcp-options-del", dhcpUUID)
+		return "", fmt.Errorf("failed to set DHCPv6 options: %w", err)
+	}
+
+	c.logger.Infof("Created DHCPv6 options for %s with UUID %s", prefix, dhcpUUID)
+	return dhcpUUID, nil
+}
+
+// UpdateDHCPv6Options rewrites an existing DHCPv6 DHCP_Options row's option
+// set in place, mirroring UpdateDHCPOptions.
+func (c *Client) UpdateDHCPv6Options(dhcpUUID, serverID string, options map[string]string) error {
+	if _, err := c.execNBCtl(dhcpv6SetArgs(dhcpUUID, serverID, options)...); err != nil {
+		return fmt.Errorf("failed to update DHCPv6 options %s: %w", dhcpUUID, err)
+	}
+
+	c.logger.Infof("Updated DHCPv6 options %s", dhcpUUID)
+	return nil
+}
+
+// SetPortDHCPv6 configures a port to use DHCPv6 options
+func (c *Client) SetPortDHCPv6(portName, dhcpOptionsUUID string) error {
+	if _, err := c.execNBCtl("lsp-set-dhcpv6-options", portName, dhcpOptionsUUID); err != nil {
+		return fmt.Errorf("failed to set DHCPv6 options on port %s: %w", portName, err)
+	}
+
+	c.logger.Infof("Set DHCPv6 options %s on port %s", dhcpOptionsUUID, portName)
+	return nil
+}
+
+// DeleteDHCPOptions deletes a DHCP_Options row (v4 or v6 - both live in the
+// same table) by UUID
+func (c *Client) DeleteDHCPOptions(dhcpOptionsUUID string) error {
+	if _, err := c.execNBCtl("dhcp-options-del", dhcpOptionsUUID); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			c.logger.Infof("DHCP options %s don't exist", dhcpOptionsUUID)
+			return nil
+		}
+		return fmt.Errorf("failed to delete DHCP options %s: %w", dhcpOptionsUUID, err)
+	}
+
+	c.logger.Infof("Deleted DHCP options %s", dhcpOptionsUUID)
+	return nil
+}
+
+// CreateLoadBalancer returns the UUID of the Load_Balancer row named name,
+// creating it (with the given protocol - "tcp", "udp", or "sctp") if it
+// doesn't already exist.
+func (c *Client) CreateLoadBalancer(name, protocol string) (string, error) {
+	output, err := c.execNBCtl("--bare", "--columns=_uuid", "find", "Load_Balancer", fmt.Sprintf("name=%s", name))
+	if err != nil {
+		return "", fmt.Errorf("failed to look up load balancer %s: %w", name, err)
+	}
+	if uuid := strings.TrimSpace(output); uuid != "" {
+		return uuid, nil
+	}
+
+	args := []string{"create", "Load_Balancer", fmt.Sprintf("name=%s", name)}
+	if protocol != "" {
+		args = append(args, fmt.Sprintf("protocol=%s", protocol))
+	}
+	uuid, err := c.execNBCtl(args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create load balancer %s: %w", name, err)
+	}
+
+	c.logger.Infof("Created load balancer %s (%s)", name, uuid)
+	return strings.TrimSpace(uuid), nil
+}
+
+// DeleteLoadBalancer deletes a load balancer and every VIP on it.
+func (c *Client) DeleteLoadBalancer(lbUUID string) error {
+	if _, err := c.execNBCtl("lb-del", lbUUID); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			c.logger.Infof("Load balancer %s doesn't exist", lbUUID)
+			return nil
+		}
+		return fmt.Errorf("failed to delete load balancer %s: %w", lbUUID, err)
+	}
+
+	c.logger.Infof("Deleted load balancer %s", lbUUID)
+	return nil
+}
+
+// AddLBVIP adds or updates a VIP on the load balancer lbUUID, forwarding it
+// to backends (each "ip:port"). vip is "ip:port" for a single-port VIP.
+func (c *Client) AddLBVIP(lbUUID, vip string, backends []string) error {
+	if len(backends) == 0 {
+		return fmt.Errorf("AddLBVIP: at least one backend is required for VIP %s", vip)
+	}
+
+	if _, err := c.execNBCtl("set", "Load_Balancer", lbUUID,
+		fmt.Sprintf(`vips:"%s"="%s"`, vip, strings.Join(backends, ","))); err != nil {
+		return fmt.Errorf("failed to set VIP %s on load balancer %s: %w", vip, lbUUID, err)
+	}
+
+	c.logger.Infof("Set load balancer %s VIP %s -> %s", lbUUID, vip, strings.Join(backends, ","))
+	return nil
+}
+
+// RemoveLBVIP removes a single VIP from the load balancer lbUUID, leaving
+// any other VIPs on it untouched.
+func (c *Client) RemoveLBVIP(lbUUID, vip string) error {
+	if _, err := c.execNBCtl("remove", "Load_Balancer", lbUUID, "vips", vip); err != nil {
+		if strings.Contains(err.Error(), "no row") {
+			return nil
+		}
+		return fmt.Errorf("failed to remove VIP %s from load balancer %s: %w", vip, lbUUID, err)
+	}
+
+	c.logger.Infof("Removed VIP %s from load balancer %s", vip, lbUUID)
+	return nil
+}
+
+// AttachLBToRouter associates load balancer lbUUID with router, so traffic
+// arriving on the router's gateway port can hit the VIP (north-south).
+func (c *Client) AttachLBToRouter(lbUUID, router string) error {
+	if _, err := c.execNBCtl("lr-lb-add", router, lbUUID); err != nil {
+		if strings.Contains(err.Error(), "already") {
+			return nil
+		}
+		return fmt.Errorf("failed to attach load balancer %s to router %s: %w", lbUUID, router, err)
+	}
+
+	c.logger.Infof("Attached load balancer %s to router %s", lbUUID, router)
+	return nil
+}
+
+// AttachLBToSwitch associates load balancer lbUUID with lswitch, so traffic
+// between endpoints on the same switch can hit the VIP directly (hairpin),
+// without a round trip through the gateway router.
+func (c *Client) AttachLBToSwitch(lbUUID, lswitch string) error {
+	if _, err := c.execNBCtl("ls-lb-add", lswitch, lbUUID); err != nil {
+		if strings.Contains(err.Error(), "already") {
+			return nil
+		}
+		return fmt.Errorf("failed to attach load balancer %s to switch %s: %w", lbUUID, lswitch, err)
+	}
+
+	c.logger.Infof("Attached load balancer %s to switch %s", lbUUID, lswitch)
+	return nil
+}
+
 // DisablePortSecurity disables port security on a logical switch port
 func (c *Client) DisablePortSecurity(portName string) error {
 	// Clear port security to allow all traffic
@@ -0,0 +1,45 @@
+package ovn
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// DHCPv4Options is a network's declarative OVN DHCPv4 configuration, as
+// parsed from its "ovn.dhcp4.*" options (see the Driver's
+// reconcileDHCPOptions).
+type DHCPv4Options struct {
+	Router    string
+	DNSServer string
+	LeaseTime string
+	MTU       string
+	Domain    string
+}
+
+// Hash returns a stable hex-encoded digest of o's content, so the Driver
+// can compare it against the PolicyInfo persisted in the store and skip
+// rewriting the DHCP_Options row when a network's ovn.dhcp4.* options
+// haven't actually changed since it was last programmed.
+func (o DHCPv4Options) Hash() string {
+	// Field order in DHCPv4Options is fixed, so json.Marshal output is
+	// deterministic for a given value - see SecurityGroupPolicy.Hash.
+	data, _ := json.Marshal(o)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// DHCPv6Options is a network's declarative OVN DHCPv6 configuration, as
+// parsed from its "ovn.dhcp6.*" options.
+type DHCPv6Options struct {
+	ServerID  string
+	DNSServer string
+}
+
+// Hash returns a stable hex-encoded digest of o's content, mirroring
+// DHCPv4Options.Hash.
+func (o DHCPv6Options) Hash() string {
+	data, _ := json.Marshal(o)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,221 @@
+package ovn
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovs/ovsdb"
+)
+
+// maxTxnRetries bounds how many times Commit retries a transaction after
+// the Northbound database reports "try again" - OVSDB's way of saying
+// another client committed first and the transaction needs to be
+// recomputed against the new state. A handful of retries clears almost
+// every occurrence; one that doesn't is a real conflict and should
+// surface as an error rather than retry forever.
+const maxTxnRetries = 3
+
+// isTryAgain reports whether err is OVSDB's "try again" transaction
+// response, the one case Commit retries automatically instead of
+// propagating to the caller.
+func isTryAgain(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "try again")
+}
+
+// SetAddresses queues replacing portName's addresses (and, to keep the two
+// in sync the way lsp-set-port-security does, its port_security) with
+// addresses, applied atomically with the rest of the transaction on Commit.
+func (t *Txn) SetAddresses(portName string, addresses []string) *Txn {
+	t.ops = append(t.ops, ovsdb.Op{
+		Op:    "update",
+		Table: "Logical_Switch_Port",
+		Where: []interface{}{[]interface{}{"name", "==", portName}},
+		Row: map[string]interface{}{
+			"addresses":     ovsdb.Set(addresses),
+			"port_security": ovsdb.Set(addresses),
+		},
+	})
+	return t
+}
+
+// AddACL queues one ACL row bound to port group pgName, mirroring
+// (*Client).AddACL's exec-based "acl-add --type=port-group" but as part of
+// a larger atomic transaction - e.g. alongside CreatePortGroup in the same
+// Commit, so a security group's port group never briefly exists without
+// its ACLs.
+func (t *Txn) AddACL(pgName, direction string, priority int, match, action string) *Txn {
+	uuidName := "acl_" + sanitizeUUIDName(fmt.Sprintf("%s_%s_%d_%s", pgName, direction, priority, match))
+
+	t.ops = append(t.ops,
+		ovsdb.Op{
+			Op:    "insert",
+			Table: "ACL",
+			Row: map[string]interface{}{
+				"direction": direction,
+				"priority":  priority,
+				"match":     match,
+				"action":    action,
+			},
+			UUIDName: uuidName,
+		},
+		ovsdb.Op{
+			Op:    "mutate",
+			Table: "Port_Group",
+			Where: []interface{}{[]interface{}{"name", "==", pgName}},
+			Mutations: []interface{}{
+				[]interface{}{"acls", "insert", []interface{}{"set", []interface{}{ovsdb.NamedUUID(uuidName)}}},
+			},
+		},
+	)
+	return t
+}
+
+// CreateLoadBalancer queues a Load_Balancer row named name for protocol
+// ("tcp"/"udp"/""), inserted under uuidName so later ops in the same
+// transaction (e.g. AttachLBToRouter) can reference it via ovsdb.NamedUUID.
+func (t *Txn) CreateLoadBalancer(uuidName, name, protocol string) *Txn {
+	row := map[string]interface{}{"name": name}
+	if protocol != "" {
+		row["protocol"] = protocol
+	}
+	t.ops = append(t.ops, ovsdb.Op{Op: "insert", Table: "Load_Balancer", Row: row, UUIDName: uuidName})
+	return t
+}
+
+// AttachLBToRouter queues adding the load balancer named by uuidName
+// (created earlier in the same transaction by CreateLoadBalancer) to
+// router's load_balancer set.
+func (t *Txn) AttachLBToRouter(uuidName, router string) *Txn {
+	t.ops = append(t.ops, ovsdb.Op{
+		Op:    "mutate",
+		Table: "Logical_Router",
+		Where: []interface{}{[]interface{}{"name", "==", router}},
+		Mutations: []interface{}{
+			[]interface{}{"load_balancer", "insert", []interface{}{"set", []interface{}{ovsdb.NamedUUID(uuidName)}}},
+		},
+	})
+	return t
+}
+
+// AttachSwitchToRouter queues the patch-port pair that connects lswitch to
+// router: a Logical_Switch_Port of type "router" on lswitch pointing at
+// routerPort via the router-port option, and the Logical_Router_Port itself
+// with mac/networks - the same pair CreateLogicalPort/CreateLogicalRouterPort
+// create via two ovn-nbctl calls each, queued here as one atomic commit.
+func (t *Txn) AttachSwitchToRouter(lswitch, switchPort, router, routerPort, mac string, networks []string) *Txn {
+	lspUUIDName := "lsp_" + sanitizeUUIDName(switchPort)
+	lrpUUIDName := "lrp_" + sanitizeUUIDName(routerPort)
+
+	t.ops = append(t.ops,
+		ovsdb.Op{
+			Op:    "insert",
+			Table: "Logical_Switch_Port",
+			Row: map[string]interface{}{
+				"name":      switchPort,
+				"type":      "router",
+				"addresses": ovsdb.Set([]string{"router"}),
+				"options":   ovsdb.StringMap(map[string]string{"router-port": routerPort}),
+			},
+			UUIDName: lspUUIDName,
+		},
+		ovsdb.Op{
+			Op:    "mutate",
+			Table: "Logical_Switch",
+			Where: []interface{}{[]interface{}{"name", "==", lswitch}},
+			Mutations: []interface{}{
+				[]interface{}{"ports", "insert", []interface{}{"set", []interface{}{ovsdb.NamedUUID(lspUUIDName)}}},
+			},
+		},
+		ovsdb.Op{
+			Op:    "insert",
+			Table: "Logical_Router_Port",
+			Row: map[string]interface{}{
+				"name":     routerPort,
+				"mac":      mac,
+				"networks": ovsdb.Set(networks),
+			},
+			UUIDName: lrpUUIDName,
+		},
+		ovsdb.Op{
+			Op:    "mutate",
+			Table: "Logical_Router",
+			Where: []interface{}{[]interface{}{"name", "==", router}},
+			Mutations: []interface{}{
+				[]interface{}{"ports", "insert", []interface{}{"set", []interface{}{ovsdb.NamedUUID(lrpUUIDName)}}},
+			},
+		},
+	)
+	return t
+}
+
+// Commit sends the queued operations as a single "transact" call, retrying
+// up to maxTxnRetries times if the Northbound database reports "try again".
+// An empty transaction is a no-op.
+func (t *Txn) Commit() error {
+	if len(t.ops) == 0 {
+		return nil
+	}
+
+	var err error
+	for attempt := 0; attempt <= maxTxnRetries; attempt++ {
+		if _, err = t.client.nb.Transact(nbDatabase, t.ops); err == nil {
+			return nil
+		}
+		if !isTryAgain(err) {
+			return fmt.Errorf("ovn: transaction failed: %w", err)
+		}
+		t.client.logger.Warnf("OVSDB transaction asked to try again (attempt %d/%d)", attempt+1, maxTxnRetries)
+		time.Sleep(time.Duration(attempt+1) * 100 * time.Millisecond)
+	}
+	return fmt.Errorf("ovn: transaction failed after %d retries: %w", maxTxnRetries, err)
+}
+
+// AttachSwitchToRouter connects lswitch to router via switchPort/routerPort
+// as a single atomic transaction when the native OVSDB connection is
+// available, so the pair can never be observed half-created; it falls back
+// to CreateLogicalRouterPort+CreateLogicalPort (two separate ovn-nbctl
+// calls) when it isn't.
+func (c *Client) AttachSwitchToRouter(lswitch, switchPort, router, routerPort, mac string, networks []string) error {
+	if c.nb != nil {
+		if txn, err := c.Begin(); err == nil {
+			if err := txn.AttachSwitchToRouter(lswitch, switchPort, router, routerPort, mac, networks).Commit(); err == nil {
+				c.logger.Infof("Attached switch %s to router %s via port %s", lswitch, router, routerPort)
+				return nil
+			} else {
+				c.logger.WithError(err).Warn("Native transaction to attach switch to router failed, falling back to ovn-nbctl")
+			}
+		}
+	}
+
+	if err := c.CreateLogicalRouterPort(router, routerPort, mac, networks); err != nil {
+		return err
+	}
+	return c.CreateLogicalPort(lswitch, switchPort, "", "", map[string]string{
+		"type":        "router",
+		"router-port": routerPort,
+	})
+}
+
+// portGroupNames returns every Port_Group's name from the client's cached
+// copy of the table - the typed read counterpart to the write-side Txn
+// helpers above, used by callers that want to check current state without
+// a round trip through ovn-nbctl. Monitor does not subscribe to Port_Group,
+// so the table is in the cache only if some other monitor on the
+// Northbound connection covers it; otherwise this returns an error.
+func (c *Client) portGroupNames() ([]string, error) {
+	if c.nb == nil {
+		return nil, fmt.Errorf("ovn: native OVSDB connection to Northbound not available")
+	}
+	rows, ok := c.nb.Cached("Port_Group")
+	if !ok {
+		return nil, fmt.Errorf("ovn: Port_Group not in cache, no monitor covers it")
+	}
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if name, ok := row["name"].(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
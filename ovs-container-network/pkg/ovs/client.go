@@ -5,23 +5,64 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/ovs-container-lab/ovs-container-network/pkg/datapath"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovs/ovsdb"
 	"github.com/sirupsen/logrus"
-	"github.com/vishvananda/netlink"
 )
 
 // Client provides an interface to Open vSwitch
 type Client struct {
-	logger *logrus.Logger
+	logger  *logrus.Logger
+	native  *ovsdb.Client    // nil if we had to fall back to shelling out to ovs-vsctl
+	backend datapath.Backend // OS-specific link-pair creation (Linux veth, FreeBSD epair, Solaris VNIC)
 }
 
-// NewClient creates a new OVS client
+// NewClient creates a new OVS client. It tries to speak OVSDB directly over
+// the management socket first, since that is faster and doesn't depend on
+// ovs-vsctl's fragile textual output; if that fails (e.g. the socket isn't
+// reachable), every method falls back to exec'ing ovs-vsctl instead.
 func NewClient() (*Client, error) {
 	logger := logrus.New()
 	logger.SetLevel(logrus.GetLevel())
 
-	return &Client{
-		logger: logger,
-	}, nil
+	backend, err := datapath.NewBackend(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select datapath backend: %w", err)
+	}
+
+	c := &Client{logger: logger, backend: backend}
+
+	native, err := ovsdb.Dial(ovsdb.DefaultSocketPath)
+	if err != nil {
+		logger.Warnf("OVSDB socket not reachable, falling back to ovs-vsctl: %v", err)
+		return c, nil
+	}
+
+	if err := native.Monitor(ovsdb.OpenVSwitchDB, map[string]ovsdb.MonitorRequest{
+		"Bridge":    {},
+		"Port":      {},
+		"Interface": {},
+		"Mirror":    {},
+	}); err != nil {
+		logger.Warnf("Failed to monitor Open_vSwitch DB, falling back to ovs-vsctl: %v", err)
+		native.Close()
+		return c, nil
+	}
+
+	c.native = native
+	logger.Info("Using native OVSDB client")
+	return c, nil
+}
+
+// Transact exposes the underlying OVSDB client's batched transact API so
+// callers can combine several row operations (e.g. bridge + port +
+// external_ids + VLAN tag) into one atomic RPC. It returns an error if the
+// client fell back to the exec path, since ovs-vsctl has no equivalent.
+func (c *Client) Transact(ops []ovsdb.Op) ([]ovsdb.OpResult, error) {
+	if c.native == nil {
+		return nil, fmt.Errorf("native OVSDB client not available, cannot batch transact")
+	}
+	return c.native.Transact(ovsdb.OpenVSwitchDB, ops)
 }
 
 // Ping verifies that OVS is accessible
@@ -37,6 +78,31 @@ func (c *Client) Ping() error {
 
 // ListBridges returns a list of all OVS bridges
 func (c *Client) ListBridges() ([]string, error) {
+	if c.native != nil {
+		if bridges, err := c.listBridgesNative(); err == nil {
+			return bridges, nil
+		} else {
+			c.logger.Warnf("Native ListBridges failed, falling back to ovs-vsctl: %v", err)
+		}
+	}
+	return c.listBridgesExec()
+}
+
+func (c *Client) listBridgesNative() ([]string, error) {
+	rows, ok := c.native.Cached("Bridge")
+	if !ok {
+		return nil, fmt.Errorf("Bridge table not monitored")
+	}
+	bridges := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if name, ok := row["name"].(string); ok {
+			bridges = append(bridges, name)
+		}
+	}
+	return bridges, nil
+}
+
+func (c *Client) listBridgesExec() ([]string, error) {
 	cmd := exec.Command("ovs-vsctl", "list-br")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -54,8 +120,133 @@ func (c *Client) ListBridges() ([]string, error) {
 	return bridges, nil
 }
 
+// ListPorts lists the names of all ports attached to a bridge.
+func (c *Client) ListPorts(bridge string) ([]string, error) {
+	if c.native != nil {
+		if ports, err := c.listPortsNative(bridge); err == nil {
+			return ports, nil
+		} else {
+			c.logger.Warnf("Native ListPorts failed for %s, falling back to ovs-vsctl: %v", bridge, err)
+		}
+	}
+	return c.listPortsExec(bridge)
+}
+
+func (c *Client) listPortsNative(bridge string) ([]string, error) {
+	bridgeRows, ok := c.native.Cached("Bridge")
+	if !ok {
+		return nil, fmt.Errorf("Bridge table not monitored")
+	}
+	rows, ok := c.native.Cached("Port")
+	if !ok {
+		return nil, fmt.Errorf("Port table not monitored")
+	}
+	var portUUIDs []string
+	found := false
+	for _, row := range bridgeRows {
+		if name, _ := row["name"].(string); name == bridge {
+			portUUIDs = fromOVSUUIDSet(row["ports"])
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("bridge %s not in cached Bridge table", bridge)
+	}
+	ports := make([]string, 0, len(portUUIDs))
+	for _, uuid := range portUUIDs {
+		if name, ok := rows[uuid]["name"].(string); ok && name != bridge {
+			ports = append(ports, name)
+		}
+	}
+	return ports, nil
+}
+
+func (c *Client) listPortsExec(bridge string) ([]string, error) {
+	cmd := exec.Command("ovs-vsctl", "list-ports", bridge)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ports on %s: %w (output: %s)", bridge, err, string(output))
+	}
+
+	ports := []string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		port := strings.TrimSpace(line)
+		if port != "" {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports, nil
+}
+
 // EnsureBridge ensures that an OVS bridge exists
 func (c *Client) EnsureBridge(bridge string) error {
+	if c.native != nil {
+		if err := c.ensureBridgeNative(bridge); err == nil {
+			return nil
+		} else {
+			c.logger.Warnf("Native EnsureBridge failed for %s, falling back to ovs-vsctl: %v", bridge, err)
+		}
+	}
+	return c.ensureBridgeExec(bridge)
+}
+
+// ensureBridgeNative creates the bridge (and its backing interface/port rows
+// and the root Open_vSwitch's reference to it) in a single transaction.
+func (c *Client) ensureBridgeNative(bridge string) error {
+	bridges, err := c.listBridgesNative()
+	if err != nil {
+		return err
+	}
+	for _, br := range bridges {
+		if br == bridge {
+			c.logger.Debugf("Bridge %s already exists", bridge)
+			return nil
+		}
+	}
+
+	c.logger.Infof("Creating OVS bridge %s (native)", bridge)
+
+	ops := []ovsdb.Op{
+		{
+			Op:       "insert",
+			Table:    "Interface",
+			Row:      map[string]interface{}{"name": bridge, "type": "internal"},
+			UUIDName: "new_iface",
+		},
+		{
+			Op:       "insert",
+			Table:    "Port",
+			Row:      map[string]interface{}{"name": bridge, "interfaces": ovsdb.NamedUUID("new_iface")},
+			UUIDName: "new_port",
+		},
+		{
+			Op:    "insert",
+			Table: "Bridge",
+			Row: map[string]interface{}{
+				"name":      bridge,
+				"ports":     ovsdb.NamedUUID("new_port"),
+				"fail_mode": "secure",
+			},
+			UUIDName: "new_bridge",
+		},
+		{
+			Op:        "mutate",
+			Table:     "Open_vSwitch",
+			Where:     []interface{}{},
+			Mutations: []interface{}{[]interface{}{"bridges", "insert", ovsdb.NamedUUID("new_bridge")}},
+		},
+	}
+
+	if _, err := c.native.Transact(ovsdb.OpenVSwitchDB, ops); err != nil {
+		return fmt.Errorf("failed to create bridge %s: %w", bridge, err)
+	}
+
+	return nil
+}
+
+func (c *Client) ensureBridgeExec(bridge string) error {
 	// Check if bridge exists
 	cmd := exec.Command("ovs-vsctl", "br-exists", bridge)
 	if err := cmd.Run(); err == nil {
@@ -82,6 +273,57 @@ func (c *Client) EnsureBridge(bridge string) error {
 
 // AddPort adds a port to an OVS bridge
 func (c *Client) AddPort(bridge, port string, options map[string]string) error {
+	if c.native != nil {
+		if err := c.addPortNative(bridge, port, options); err == nil {
+			return nil
+		} else {
+			c.logger.Warnf("Native AddPort failed for %s, falling back to ovs-vsctl: %v", port, err)
+		}
+	}
+	return c.addPortExec(bridge, port, options)
+}
+
+// addPortNative inserts the Interface and Port rows and attaches the new
+// port to the bridge's "ports" set, all in one transaction.
+func (c *Client) addPortNative(bridge, port string, options map[string]string) error {
+	ifaceRow := map[string]interface{}{"name": port}
+	portRow := map[string]interface{}{"name": port, "interfaces": ovsdb.NamedUUID("new_iface")}
+
+	externalIDs := map[string]string{}
+	for key, value := range options {
+		switch {
+		case key == "tag":
+			portRow["tag"] = value
+		case strings.HasPrefix(key, "external_ids:"):
+			externalIDs[strings.TrimPrefix(key, "external_ids:")] = value
+		default:
+			externalIDs[key] = value
+		}
+	}
+	if len(externalIDs) > 0 {
+		ifaceRow["external_ids"] = toOVSMap(externalIDs)
+	}
+
+	ops := []ovsdb.Op{
+		{Op: "insert", Table: "Interface", Row: ifaceRow, UUIDName: "new_iface"},
+		{Op: "insert", Table: "Port", Row: portRow, UUIDName: "new_port"},
+		{
+			Op:        "mutate",
+			Table:     "Bridge",
+			Where:     []interface{}{[]interface{}{"name", "==", bridge}},
+			Mutations: []interface{}{[]interface{}{"ports", "insert", ovsdb.NamedUUID("new_port")}},
+		},
+	}
+
+	if _, err := c.native.Transact(ovsdb.OpenVSwitchDB, ops); err != nil {
+		return fmt.Errorf("failed to add port %s to bridge %s: %w", port, bridge, err)
+	}
+
+	c.logger.Infof("Added port %s to bridge %s (native)", port, bridge)
+	return nil
+}
+
+func (c *Client) addPortExec(bridge, port string, options map[string]string) error {
 	args := []string{"add-port", bridge, port}
 
 	// Separate options by table
@@ -124,6 +366,58 @@ func (c *Client) AddPort(bridge, port string, options map[string]string) error {
 
 // DeletePort removes a port from an OVS bridge
 func (c *Client) DeletePort(bridge, port string) error {
+	if c.native != nil {
+		if err := c.deletePortNative(bridge, port); err == nil {
+			return nil
+		} else {
+			c.logger.Warnf("Native DeletePort failed for %s, falling back to ovs-vsctl: %v", port, err)
+		}
+	}
+	return c.deletePortExec(bridge, port)
+}
+
+func (c *Client) deletePortNative(bridge, port string) error {
+	rows, ok := c.native.Cached("Port")
+	if !ok {
+		return fmt.Errorf("Port table not monitored")
+	}
+
+	var portUUID string
+	for uuid, row := range rows {
+		if name, _ := row["name"].(string); name == port {
+			portUUID = uuid
+			break
+		}
+	}
+	if portUUID == "" {
+		// The cache holds only the startup Monitor snapshot, so a miss may be
+		// a port added since; the ovs-vsctl fallback settles it.
+		return fmt.Errorf("port %s not in cached Port table", port)
+	}
+
+	ops := []ovsdb.Op{
+		{
+			Op:        "mutate",
+			Table:     "Bridge",
+			Where:     []interface{}{[]interface{}{"name", "==", bridge}},
+			Mutations: []interface{}{[]interface{}{"ports", "delete", ovsdb.UUID(portUUID)}},
+		},
+		{
+			Op:    "delete",
+			Table: "Port",
+			Where: []interface{}{[]interface{}{"_uuid", "==", ovsdb.UUID(portUUID)}},
+		},
+	}
+
+	if _, err := c.native.Transact(ovsdb.OpenVSwitchDB, ops); err != nil {
+		return fmt.Errorf("failed to delete port %s from bridge %s: %w", port, bridge, err)
+	}
+
+	c.logger.Infof("Deleted port %s from bridge %s (native)", port, bridge)
+	return nil
+}
+
+func (c *Client) deletePortExec(bridge, port string) error {
 	cmd := exec.Command("ovs-vsctl", "--if-exists", "del-port", bridge, port)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -134,71 +428,99 @@ func (c *Client) DeletePort(bridge, port string) error {
 	return nil
 }
 
-// CreateVethPair creates a veth pair
+// CreateVethPair creates a pair of connected host-side interfaces via the
+// platform's datapath backend (veth on Linux, epair on FreeBSD). On Solaris
+// it creates a single VNIC named vethName over the existing link peerName.
 func (c *Client) CreateVethPair(vethName, peerName string) error {
-	// Check if veth already exists
-	if _, err := netlink.LinkByName(vethName); err == nil {
-		c.logger.Warnf("Veth %s already exists, deleting it", vethName)
-		// Try to delete existing veth
-		if link, err := netlink.LinkByName(vethName); err == nil {
-			netlink.LinkDel(link)
-		}
-	}
+	return c.backend.CreateLinkPair(vethName, peerName)
+}
 
-	// Create the veth pair
-	veth := &netlink.Veth{
-		LinkAttrs: netlink.LinkAttrs{
-			Name: vethName,
-		},
-		PeerName: peerName,
-	}
+// DeleteVethPair deletes a link pair previously created with CreateVethPair.
+func (c *Client) DeleteVethPair(vethName, peerName string) error {
+	return c.backend.DeleteLinkPair(vethName, peerName)
+}
+
+// GetLinkMAC returns the hardware address of a host-side interface created
+// by CreateVethPair, via the platform's datapath backend.
+func (c *Client) GetLinkMAC(name string) (string, error) {
+	return c.backend.LinkMAC(name)
+}
+
+// MoveToNamespace moves a host-side interface into the network namespace at
+// nsPath, via the platform's datapath backend.
+func (c *Client) MoveToNamespace(name, nsPath string) error {
+	return c.backend.MoveToNamespace(name, nsPath)
+}
 
-	if err := netlink.LinkAdd(veth); err != nil {
-		return fmt.Errorf("failed to create veth pair %s <-> %s: %w", vethName, peerName, err)
+// ConfigureEndpointInNamespace moves ifaceName into the namespace at nsPath,
+// renames it to containerIfName, brings it up, and assigns ipAddr (CIDR) and
+// a default route via gateway inside that namespace. For Docker, libnetwork
+// itself does this move/rename/configure step using the InterfaceName
+// returned from Join; CNI has no equivalent external daemon, so the CNI
+// server drives it directly via this method instead.
+func (c *Client) ConfigureEndpointInNamespace(ifaceName, nsPath, containerIfName, ipAddr, gateway string) error {
+	if err := c.MoveToNamespace(ifaceName, nsPath); err != nil {
+		return fmt.Errorf("failed to move %s into namespace %s: %w", ifaceName, nsPath, err)
 	}
 
-	// Bring up both interfaces
-	if link, err := netlink.LinkByName(vethName); err == nil {
-		if err := netlink.LinkSetUp(link); err != nil {
-			c.logger.Warnf("Failed to bring up %s: %v", vethName, err)
+	nsExec := func(args ...string) error {
+		cmd := exec.Command("nsenter", append([]string{"--net=" + nsPath}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("nsenter %s: %w (output: %s)", strings.Join(args, " "), err, string(output))
 		}
+		return nil
 	}
 
-	if link, err := netlink.LinkByName(peerName); err == nil {
-		if err := netlink.LinkSetUp(link); err != nil {
-			c.logger.Warnf("Failed to bring up %s: %v", peerName, err)
+	if err := nsExec("ip", "link", "set", ifaceName, "name", containerIfName); err != nil {
+		return fmt.Errorf("failed to rename %s to %s in namespace: %w", ifaceName, containerIfName, err)
+	}
+	if err := nsExec("ip", "link", "set", containerIfName, "up"); err != nil {
+		return fmt.Errorf("failed to bring up %s in namespace: %w", containerIfName, err)
+	}
+	if ipAddr != "" {
+		if err := nsExec("ip", "addr", "add", ipAddr, "dev", containerIfName); err != nil {
+			return fmt.Errorf("failed to configure address %s on %s in namespace: %w", ipAddr, containerIfName, err)
+		}
+	}
+	if gateway != "" {
+		if err := nsExec("ip", "route", "add", "default", "via", gateway); err != nil {
+			c.logger.WithError(err).Warnf("Failed to add default route via %s in namespace %s", gateway, nsPath)
 		}
 	}
 
-	c.logger.Infof("Created veth pair %s <-> %s", vethName, peerName)
+	c.logger.Infof("Configured %s as %s in namespace %s", ifaceName, containerIfName, nsPath)
 	return nil
 }
 
-// DeleteVethPair deletes a veth pair
-func (c *Client) DeleteVethPair(vethName, peerName string) error {
-	// Deleting one end of a veth pair deletes both
-	if link, err := netlink.LinkByName(vethName); err == nil {
-		if err := netlink.LinkDel(link); err != nil {
-			c.logger.Warnf("Failed to delete veth %s: %v", vethName, err)
+// SetPortVLAN sets the VLAN tag for a port
+func (c *Client) SetPortVLAN(port string, vlan int) error {
+	if c.native != nil {
+		if err := c.setPortVLANNative(port, vlan); err == nil {
+			return nil
 		} else {
-			c.logger.Infof("Deleted veth pair %s <-> %s", vethName, peerName)
-		}
-	} else {
-		// Try the peer name
-		if link, err := netlink.LinkByName(peerName); err == nil {
-			if err := netlink.LinkDel(link); err != nil {
-				c.logger.Warnf("Failed to delete veth %s: %v", peerName, err)
-			} else {
-				c.logger.Infof("Deleted veth pair via peer %s", peerName)
-			}
+			c.logger.Warnf("Native SetPortVLAN failed for %s, falling back to ovs-vsctl: %v", port, err)
 		}
 	}
+	return c.setPortVLANExec(port, vlan)
+}
 
+func (c *Client) setPortVLANNative(port string, vlan int) error {
+	ops := []ovsdb.Op{
+		{
+			Op:    "update",
+			Table: "Port",
+			Where: []interface{}{[]interface{}{"name", "==", port}},
+			Row:   map[string]interface{}{"tag": vlan},
+		},
+	}
+	if _, err := c.native.Transact(ovsdb.OpenVSwitchDB, ops); err != nil {
+		return fmt.Errorf("failed to set VLAN %d on port %s: %w", vlan, port, err)
+	}
+	c.logger.Infof("Set VLAN %d on port %s (native)", vlan, port)
 	return nil
 }
 
-// SetPortVLAN sets the VLAN tag for a port
-func (c *Client) SetPortVLAN(port string, vlan int) error {
+func (c *Client) setPortVLANExec(port string, vlan int) error {
 	cmd := exec.Command("ovs-vsctl", "set", "port", port, fmt.Sprintf("tag=%d", vlan))
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -211,6 +533,52 @@ func (c *Client) SetPortVLAN(port string, vlan int) error {
 
 // GetPortInfo retrieves information about a port
 func (c *Client) GetPortInfo(port string) (map[string]string, error) {
+	if c.native != nil {
+		if info, err := c.getPortInfoNative(port); err == nil {
+			return info, nil
+		} else {
+			c.logger.Warnf("Native GetPortInfo failed for %s, falling back to ovs-vsctl: %v", port, err)
+		}
+	}
+	return c.getPortInfoExec(port)
+}
+
+func (c *Client) getPortInfoNative(port string) (map[string]string, error) {
+	portRows, ok := c.native.Cached("Port")
+	if !ok {
+		return nil, fmt.Errorf("Port table not monitored")
+	}
+	ifaceRows, ok := c.native.Cached("Interface")
+	if !ok {
+		return nil, fmt.Errorf("Interface table not monitored")
+	}
+
+	info := make(map[string]string)
+
+	for _, row := range ifaceRows {
+		if name, _ := row["name"].(string); name != port {
+			continue
+		}
+		for k, v := range fromOVSMap(row["external_ids"]) {
+			info["external_id:"+k] = v
+		}
+		break
+	}
+
+	for _, row := range portRows {
+		if name, _ := row["name"].(string); name != port {
+			continue
+		}
+		if tag := fromOVSInt(row["tag"]); tag != "" {
+			info["vlan"] = tag
+		}
+		break
+	}
+
+	return info, nil
+}
+
+func (c *Client) getPortInfoExec(port string) (map[string]string, error) {
 	info := make(map[string]string)
 
 	// Get external_ids
@@ -249,6 +617,69 @@ func (c *Client) GetPortInfo(port string) (map[string]string, error) {
 
 // CreateMirror sets up port mirroring
 func (c *Client) CreateMirror(bridge, mirrorName, sourcePort, outputPort string, options map[string]string) error {
+	if c.native != nil {
+		if err := c.createMirrorNative(bridge, mirrorName, sourcePort, outputPort); err == nil {
+			return nil
+		} else {
+			c.logger.Warnf("Native CreateMirror failed for %s, falling back to ovs-vsctl: %v", mirrorName, err)
+		}
+	}
+	return c.createMirrorExec(bridge, mirrorName, sourcePort, outputPort, options)
+}
+
+// createMirrorNative replaces the `--id=@m` ovs-vsctl gymnastics with
+// named-uuid references within a single transaction.
+func (c *Client) createMirrorNative(bridge, mirrorName, sourcePort, outputPort string) error {
+	portRows, ok := c.native.Cached("Port")
+	if !ok {
+		return fmt.Errorf("Port table not monitored")
+	}
+
+	var srcUUID, dstUUID string
+	for uuid, row := range portRows {
+		name, _ := row["name"].(string)
+		if name == sourcePort {
+			srcUUID = uuid
+		}
+		if name == outputPort {
+			dstUUID = uuid
+		}
+	}
+	if sourcePort != "" && srcUUID == "" {
+		return fmt.Errorf("source port %s not found", sourcePort)
+	}
+	if outputPort != "" && dstUUID == "" {
+		return fmt.Errorf("output port %s not found", outputPort)
+	}
+
+	mirrorRow := map[string]interface{}{"name": mirrorName}
+	if srcUUID != "" {
+		mirrorRow["select_src_port"] = toOVSSet([]string{srcUUID})
+		mirrorRow["select_dst_port"] = toOVSSet([]string{srcUUID})
+	}
+	if dstUUID != "" {
+		mirrorRow["output_port"] = ovsdb.UUID(dstUUID)
+	}
+
+	ops := []ovsdb.Op{
+		{Op: "insert", Table: "Mirror", Row: mirrorRow, UUIDName: "new_mirror"},
+		{
+			Op:        "mutate",
+			Table:     "Bridge",
+			Where:     []interface{}{[]interface{}{"name", "==", bridge}},
+			Mutations: []interface{}{[]interface{}{"mirrors", "insert", ovsdb.NamedUUID("new_mirror")}},
+		},
+	}
+
+	if _, err := c.native.Transact(ovsdb.OpenVSwitchDB, ops); err != nil {
+		return fmt.Errorf("failed to create mirror %s: %w", mirrorName, err)
+	}
+
+	c.logger.Infof("Created mirror %s on bridge %s (native)", mirrorName, bridge)
+	return nil
+}
+
+func (c *Client) createMirrorExec(bridge, mirrorName, sourcePort, outputPort string, options map[string]string) error {
 	// Build the command to create a mirror
 	args := []string{
 		"--", "--id=@m", "create", "mirror",
@@ -290,6 +721,58 @@ func (c *Client) CreateMirror(bridge, mirrorName, sourcePort, outputPort string,
 
 // DeleteMirror removes a port mirror
 func (c *Client) DeleteMirror(bridge, mirrorName string) error {
+	if c.native != nil {
+		if err := c.deleteMirrorNative(bridge, mirrorName); err == nil {
+			return nil
+		} else {
+			c.logger.Warnf("Native DeleteMirror failed for %s, falling back to ovs-vsctl: %v", mirrorName, err)
+		}
+	}
+	return c.deleteMirrorExec(bridge, mirrorName)
+}
+
+func (c *Client) deleteMirrorNative(bridge, mirrorName string) error {
+	rows, ok := c.native.Cached("Mirror")
+	if !ok {
+		return fmt.Errorf("Mirror table not monitored")
+	}
+
+	var mirrorUUID string
+	for uuid, row := range rows {
+		if name, _ := row["name"].(string); name == mirrorName {
+			mirrorUUID = uuid
+			break
+		}
+	}
+	if mirrorUUID == "" {
+		// As in deletePortNative, a cache miss may be a mirror added after
+		// the startup snapshot; the ovs-vsctl fallback settles it.
+		return fmt.Errorf("mirror %s not in cached Mirror table", mirrorName)
+	}
+
+	ops := []ovsdb.Op{
+		{
+			Op:        "mutate",
+			Table:     "Bridge",
+			Where:     []interface{}{[]interface{}{"name", "==", bridge}},
+			Mutations: []interface{}{[]interface{}{"mirrors", "delete", ovsdb.UUID(mirrorUUID)}},
+		},
+		{
+			Op:    "delete",
+			Table: "Mirror",
+			Where: []interface{}{[]interface{}{"_uuid", "==", ovsdb.UUID(mirrorUUID)}},
+		},
+	}
+
+	if _, err := c.native.Transact(ovsdb.OpenVSwitchDB, ops); err != nil {
+		return fmt.Errorf("failed to delete mirror %s: %w", mirrorName, err)
+	}
+
+	c.logger.Infof("Deleted mirror %s (native)", mirrorName)
+	return nil
+}
+
+func (c *Client) deleteMirrorExec(bridge, mirrorName string) error {
 	// First, clear the mirror from the bridge
 	cmd := exec.Command("ovs-vsctl", "remove", "bridge", bridge, "mirrors", mirrorName)
 	output, err := cmd.CombinedOutput()
@@ -310,6 +793,48 @@ func (c *Client) DeleteMirror(bridge, mirrorName string) error {
 
 // ListMirrors lists all mirrors on a bridge
 func (c *Client) ListMirrors(bridge string) ([]string, error) {
+	if c.native != nil {
+		if mirrors, err := c.listMirrorsNative(bridge); err == nil {
+			return mirrors, nil
+		} else {
+			c.logger.Warnf("Native ListMirrors failed for %s, falling back to ovs-vsctl: %v", bridge, err)
+		}
+	}
+	return c.listMirrorsExec(bridge)
+}
+
+func (c *Client) listMirrorsNative(bridge string) ([]string, error) {
+	bridgeRows, ok := c.native.Cached("Bridge")
+	if !ok {
+		return nil, fmt.Errorf("Bridge table not monitored")
+	}
+	mirrorRows, ok := c.native.Cached("Mirror")
+	if !ok {
+		return nil, fmt.Errorf("Mirror table not monitored")
+	}
+
+	var mirrorUUIDs []string
+	for _, row := range bridgeRows {
+		if name, _ := row["name"].(string); name != bridge {
+			continue
+		}
+		mirrorUUIDs = fromOVSUUIDSet(row["mirrors"])
+		break
+	}
+
+	mirrors := make([]string, 0, len(mirrorUUIDs))
+	for _, uuid := range mirrorUUIDs {
+		if row, ok := mirrorRows[uuid]; ok {
+			if name, _ := row["name"].(string); name != "" {
+				mirrors = append(mirrors, name)
+			}
+		}
+	}
+
+	return mirrors, nil
+}
+
+func (c *Client) listMirrorsExec(bridge string) ([]string, error) {
 	cmd := exec.Command("ovs-vsctl", "get", "bridge", bridge, "mirrors")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -342,3 +867,136 @@ func (c *Client) ListMirrors(bridge string) ([]string, error) {
 
 	return mirrors, nil
 }
+
+// BridgeMappings returns this chassis's "ovn-bridge-mappings" external-id
+// off the Open_vSwitch table's sole row, e.g. "physnet1:br-provider", the
+// same mapping ovn-controller consults to resolve a localnet port's
+// physical network name to a bridge. Returns "" if unset.
+func (c *Client) BridgeMappings() (string, error) {
+	if c.native != nil {
+		if mappings, err := c.bridgeMappingsNative(); err == nil {
+			return mappings, nil
+		} else {
+			c.logger.Warnf("Native BridgeMappings failed, falling back to ovs-vsctl: %v", err)
+		}
+	}
+	return c.bridgeMappingsExec()
+}
+
+func (c *Client) bridgeMappingsNative() (string, error) {
+	rows, ok := c.native.Cached(ovsdb.OpenVSwitchDB)
+	if !ok {
+		return "", fmt.Errorf("Open_vSwitch table not monitored")
+	}
+	for _, row := range rows {
+		return fromOVSMap(row["external_ids"])["ovn-bridge-mappings"], nil
+	}
+	return "", nil
+}
+
+func (c *Client) bridgeMappingsExec() (string, error) {
+	cmd := exec.Command("ovs-vsctl", "get", "open_vswitch", ".", "external_ids:ovn-bridge-mappings")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "no key") {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get bridge mappings: %w", err)
+	}
+	return strings.Trim(strings.TrimSpace(string(output)), "\""), nil
+}
+
+// toOVSMap converts a plain string map into OVSDB's wire representation of
+// the "map" column type: ["map", [[k1,v1], [k2,v2], ...]].
+func toOVSMap(m map[string]string) []interface{} {
+	pairs := make([][]interface{}, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, []interface{}{k, v})
+	}
+	return []interface{}{"map", pairs}
+}
+
+// fromOVSMap parses OVSDB's wire representation of a "map" column back into
+// a plain string map.
+func fromOVSMap(v interface{}) map[string]string {
+	result := make(map[string]string)
+	pair, ok := v.([]interface{})
+	if !ok || len(pair) != 2 || pair[0] != "map" {
+		return result
+	}
+	entries, ok := pair[1].([]interface{})
+	if !ok {
+		return result
+	}
+	for _, e := range entries {
+		kv, ok := e.([]interface{})
+		if !ok || len(kv) != 2 {
+			continue
+		}
+		key, _ := kv[0].(string)
+		value, _ := kv[1].(string)
+		result[key] = value
+	}
+	return result
+}
+
+// toOVSSet converts a list of UUIDs into OVSDB's wire representation of the
+// "set" column type.
+func toOVSSet(uuids []string) []interface{} {
+	refs := make([]interface{}, 0, len(uuids))
+	for _, u := range uuids {
+		refs = append(refs, ovsdb.UUID(u))
+	}
+	return []interface{}{"set", refs}
+}
+
+// fromOVSUUIDSet parses either a bare ["uuid", id] reference or a
+// ["set", [...]] of such references into a slice of UUID strings.
+func fromOVSUUIDSet(v interface{}) []string {
+	pair, ok := v.([]interface{})
+	if !ok || len(pair) != 2 {
+		return nil
+	}
+
+	if pair[0] == "uuid" {
+		if id, ok := pair[1].(string); ok {
+			return []string{id}
+		}
+		return nil
+	}
+
+	if pair[0] != "set" {
+		return nil
+	}
+
+	entries, ok := pair[1].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var uuids []string
+	for _, e := range entries {
+		ref, ok := e.([]interface{})
+		if !ok || len(ref) != 2 || ref[0] != "uuid" {
+			continue
+		}
+		if id, ok := ref[1].(string); ok {
+			uuids = append(uuids, id)
+		}
+	}
+	return uuids
+}
+
+// fromOVSInt parses OVSDB's wire representation of an optional integer
+// column (either a bare number or ["set", []] for "not set") into a string,
+// returning "" when unset.
+func fromOVSInt(v interface{}) string {
+	switch t := v.(type) {
+	case float64:
+		return fmt.Sprintf("%d", int(t))
+	case []interface{}:
+		return ""
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,252 @@
+package ovsdb
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startMockServer spins up a Unix socket listener that decodes one JSON-RPC
+// request at a time and replies with a canned result, simulating just
+// enough of ovsdb-server's wire behaviour to exercise the client.
+func startMockServer(t *testing.T, handle func(method string, params []interface{}) interface{}) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	sockPath := dir + "/db.sock"
+
+	listener, err := net.Listen("unix", sockPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		for {
+			dec := json.NewDecoder(reader)
+			var req rpcRequest
+			if err := dec.Decode(&req); err != nil {
+				return
+			}
+
+			result := handle(req.Method, req.Params)
+			resultBytes, _ := json.Marshal(result)
+
+			resp := rpcResponse{
+				Result: resultBytes,
+				ID:     req.ID,
+			}
+			if err := json.NewEncoder(conn).Encode(resp); err != nil {
+				return
+			}
+		}
+	}()
+
+	return sockPath
+}
+
+func TestTransactInsert(t *testing.T) {
+	sockPath := startMockServer(t, func(method string, params []interface{}) interface{} {
+		assert.Equal(t, "transact", method)
+		return []OpResult{
+			{UUID: []interface{}{"uuid", "11111111-1111-1111-1111-111111111111"}},
+		}
+	})
+
+	client, err := Dial(sockPath)
+	require.NoError(t, err)
+	defer client.Close()
+
+	results, err := client.Transact(OpenVSwitchDB, []Op{
+		{Op: "insert", Table: "Bridge", Row: map[string]interface{}{"name": "br-test"}, UUIDName: "new_bridge"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", results[0].UUID[1])
+}
+
+func TestTransactError(t *testing.T) {
+	sockPath := startMockServer(t, func(method string, params []interface{}) interface{} {
+		return []OpResult{
+			{Error: "constraint violation", Details: "duplicate row"},
+		}
+	})
+
+	client, err := Dial(sockPath)
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Transact(OpenVSwitchDB, []Op{
+		{Op: "insert", Table: "Bridge", Row: map[string]interface{}{"name": "br-test"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "constraint violation")
+}
+
+func TestMonitorPopulatesCache(t *testing.T) {
+	sockPath := startMockServer(t, func(method string, params []interface{}) interface{} {
+		assert.Equal(t, "monitor", method)
+		return map[string]map[string]interface{}{
+			"Bridge": {
+				"b1": map[string]interface{}{
+					"new": map[string]interface{}{"name": "br-int"},
+				},
+			},
+		}
+	})
+
+	client, err := Dial(sockPath)
+	require.NoError(t, err)
+	defer client.Close()
+
+	err = client.Monitor(OpenVSwitchDB, map[string]MonitorRequest{"Bridge": {}})
+	require.NoError(t, err)
+
+	rows, ok := client.Cached("Bridge")
+	require.True(t, ok)
+	require.Contains(t, rows, "b1")
+	assert.Equal(t, "br-int", rows["b1"]["name"])
+}
+
+func TestNamedUUIDAndUUIDHelpers(t *testing.T) {
+	assert.Equal(t, []interface{}{"named-uuid", "foo"}, NamedUUID("foo"))
+	assert.Equal(t, []interface{}{"uuid", "bar"}, UUID("bar"))
+}
+
+func TestSetAndStringMapHelpers(t *testing.T) {
+	assert.Equal(t, []interface{}{"set", []interface{}{"a", "b"}}, Set([]string{"a", "b"}))
+	assert.Equal(t, []interface{}{"set", []interface{}{}}, Set(nil))
+	assert.Equal(t, []interface{}{"map", []interface{}{[]interface{}{"k", "v"}}}, StringMap(map[string]string{"k": "v"}))
+	assert.Equal(t, []interface{}{"map", []interface{}{}}, StringMap(nil))
+}
+
+func TestDialTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		dec := json.NewDecoder(reader)
+		var req rpcRequest
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+		resultBytes, _ := json.Marshal([]OpResult{{Count: 1}})
+		json.NewEncoder(conn).Encode(rpcResponse{Result: resultBytes, ID: req.ID})
+	}()
+
+	client, err := DialTCP(listener.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	results, err := client.Transact(OpenVSwitchDB, []Op{{Op: "update", Table: "Bridge"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 1, results[0].Count)
+}
+
+func TestGetSchemaCachesResult(t *testing.T) {
+	calls := 0
+	sockPath := startMockServer(t, func(method string, params []interface{}) interface{} {
+		assert.Equal(t, "get_schema", method)
+		calls++
+		return map[string]interface{}{"name": OpenVSwitchDB, "version": "8.2.0"}
+	})
+
+	client, err := Dial(sockPath)
+	require.NoError(t, err)
+	defer client.Close()
+
+	schema1, err := client.GetSchema(OpenVSwitchDB)
+	require.NoError(t, err)
+	schema2, err := client.GetSchema(OpenVSwitchDB)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(schema1), string(schema2))
+	assert.Equal(t, 1, calls, "expected GetSchema to cache the result instead of re-fetching")
+}
+
+func TestMonitorCondPopulatesCache(t *testing.T) {
+	sockPath := startMockServer(t, func(method string, params []interface{}) interface{} {
+		assert.Equal(t, "monitor_cond", method)
+		return map[string]map[string]interface{}{
+			"Port_Binding": {
+				"pb1": map[string]interface{}{
+					"initial": map[string]interface{}{"logical_port": "lsp1", "chassis": "ch1"},
+				},
+			},
+		}
+	})
+
+	client, err := Dial(sockPath)
+	require.NoError(t, err)
+	defer client.Close()
+
+	err = client.MonitorCond("OVN_Southbound", map[string][]Condition{
+		"Port_Binding": {{"chassis", "==", "ch1"}},
+	})
+	require.NoError(t, err)
+
+	rows, ok := client.Cached("Port_Binding")
+	require.True(t, ok)
+	require.Contains(t, rows, "pb1")
+	assert.Equal(t, "lsp1", rows["pb1"]["logical_port"])
+}
+
+func TestListenDeliversUpdate2Notifications(t *testing.T) {
+	dir := t.TempDir()
+	sockPath := dir + "/db.sock"
+
+	listener, err := net.Listen("unix", sockPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		enc := json.NewEncoder(conn)
+		enc.Encode(map[string]interface{}{
+			"method": "update2",
+			"params": []interface{}{
+				"ovs-container-network",
+				map[string]interface{}{
+					"Port_Binding": map[string]interface{}{
+						"pb1": map[string]interface{}{
+							"insert": map[string]interface{}{"logical_port": "lsp1", "chassis": "ch1"},
+						},
+					},
+				},
+			},
+		})
+	}()
+
+	client, err := Dial(sockPath)
+	require.NoError(t, err)
+	defer client.Close()
+
+	update := <-client.Listen()
+	assert.Equal(t, "Port_Binding", update.Table)
+	assert.Equal(t, "pb1", update.UUID)
+	assert.False(t, update.Delete)
+	assert.Equal(t, "lsp1", update.Row["logical_port"])
+}
@@ -0,0 +1,137 @@
+// Package portmap allocates host ports for published container ports
+// ("-p hostPort:containerPort/proto", optionally a range) and tracks what's
+// currently in use, the same way pkg/snat tracks infra-vnet address
+// allocations, so Driver.recoverState can restore reservations across a
+// plugin restart without re-picking a port a live endpoint already holds.
+package portmap
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ephemeralStart/ephemeralEnd bound the range Allocate picks from when the
+// caller doesn't request a specific host port, matching the low end of the
+// Linux default ephemeral port range so picked ports don't collide with
+// outbound connections.
+const (
+	ephemeralStart = 30000
+	ephemeralEnd   = 60000
+)
+
+// Allocation records one host port (or port range) reserved for an
+// endpoint's published container port.
+type Allocation struct {
+	NetworkID     string
+	EndpointID    string
+	Proto         string // "tcp", "udp", or "sctp"
+	ContainerPort uint16
+	HostIP        string
+	HostPort      uint16
+	HostPortEnd   uint16 // equal to HostPort for a single-port binding
+}
+
+// Manager allocates host ports and keeps an in-memory record of what it has
+// handed out, keyed by networkID:endpointID.
+type Manager struct {
+	logger *logrus.Logger
+
+	mu          sync.Mutex
+	allocations map[string][]Allocation
+	used        map[string]bool // "proto:hostIP:hostPort"
+}
+
+// NewManager creates a port-publishing manager.
+func NewManager(logger *logrus.Logger) *Manager {
+	return &Manager{
+		logger:      logger,
+		allocations: make(map[string][]Allocation),
+		used:        make(map[string]bool),
+	}
+}
+
+func key(networkID, endpointID string) string {
+	return networkID + ":" + endpointID
+}
+
+func usedKey(proto, hostIP string, port uint16) string {
+	return fmt.Sprintf("%s:%s:%d", proto, hostIP, port)
+}
+
+// Allocate reserves hostPort through hostPortEnd (a zero hostPortEnd means a
+// single port) for containerPort and returns the reserved range. If
+// hostPort is 0, the next free port in the ephemeral range is picked
+// instead, matching Docker's own "-p containerPort" (no host port) behavior.
+func (m *Manager) Allocate(networkID, endpointID, proto, hostIP string, hostPort, hostPortEnd, containerPort uint16) (uint16, uint16, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if hostPort == 0 {
+		for p := ephemeralStart; p <= ephemeralEnd; p++ {
+			if !m.used[usedKey(proto, hostIP, uint16(p))] {
+				m.reserveLocked(networkID, endpointID, proto, hostIP, uint16(p), uint16(p), containerPort)
+				return uint16(p), uint16(p), nil
+			}
+		}
+		return 0, 0, fmt.Errorf("portmap: no free host port available for %s/%s", proto, hostIP)
+	}
+
+	if hostPortEnd == 0 {
+		hostPortEnd = hostPort
+	}
+	for p := hostPort; p <= hostPortEnd; p++ {
+		if m.used[usedKey(proto, hostIP, p)] {
+			return 0, 0, fmt.Errorf("portmap: host port %d/%s already in use on %s", p, proto, hostIP)
+		}
+	}
+	m.reserveLocked(networkID, endpointID, proto, hostIP, hostPort, hostPortEnd, containerPort)
+	return hostPort, hostPortEnd, nil
+}
+
+// Reserve records an already-allocated range (e.g. one loaded from the
+// store during recoverState) without handing out a new one, so a later
+// Allocate call for a different endpoint doesn't collide with it.
+func (m *Manager) Reserve(networkID, endpointID, proto, hostIP string, hostPort, hostPortEnd, containerPort uint16) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reserveLocked(networkID, endpointID, proto, hostIP, hostPort, hostPortEnd, containerPort)
+}
+
+func (m *Manager) reserveLocked(networkID, endpointID, proto, hostIP string, hostPort, hostPortEnd, containerPort uint16) {
+	for p := hostPort; p <= hostPortEnd; p++ {
+		m.used[usedKey(proto, hostIP, p)] = true
+	}
+	k := key(networkID, endpointID)
+	m.allocations[k] = append(m.allocations[k], Allocation{
+		NetworkID:     networkID,
+		EndpointID:    endpointID,
+		Proto:         proto,
+		ContainerPort: containerPort,
+		HostIP:        hostIP,
+		HostPort:      hostPort,
+		HostPortEnd:   hostPortEnd,
+	})
+}
+
+// Release frees every port range this endpoint had reserved.
+func (m *Manager) Release(networkID, endpointID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k := key(networkID, endpointID)
+	for _, a := range m.allocations[k] {
+		for p := a.HostPort; p <= a.HostPortEnd; p++ {
+			delete(m.used, usedKey(a.Proto, a.HostIP, p))
+		}
+	}
+	delete(m.allocations, k)
+}
+
+// InUse reports whether hostPort/proto on hostIP is currently reserved.
+func (m *Manager) InUse(proto, hostIP string, hostPort uint16) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.used[usedKey(proto, hostIP, hostPort)]
+}
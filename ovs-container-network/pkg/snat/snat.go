@@ -0,0 +1,195 @@
+// Package snat manages per-endpoint SNAT-on-host state: allocating
+// addresses out of a per-tenant infra-vnet pool and programming the host
+// netns iptables rule that rewrites an endpoint's egress to that address.
+// It gives multi-tenant deployments a way to reach host-external resources
+// without standing up an OVN gateway router per tenant.
+package snat
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Allocation records the infra-vnet address handed out to one endpoint.
+type Allocation struct {
+	NetworkID   string
+	EndpointID  string
+	PodAddress  string // the endpoint's own IP, whose egress is being rewritten
+	InfraVNetIP string // the address traffic is SNATed to
+	CIDR        string // the pool InfraVNetIP was allocated from
+}
+
+// Manager allocates infra-vnet addresses per network and programs the
+// corresponding SNAT rules. It keeps an in-memory record of what it has
+// allocated and programmed so Recover can detect and repair drift after a
+// plugin restart.
+type Manager struct {
+	logger *logrus.Logger
+
+	mu          sync.Mutex
+	allocations map[string]*Allocation     // keyed by networkID:endpointID
+	used        map[string]map[string]bool // networkID -> set of allocated infra-vnet IPs
+}
+
+// NewManager creates a SNAT-on-host manager.
+func NewManager(logger *logrus.Logger) *Manager {
+	return &Manager{
+		logger:      logger,
+		allocations: make(map[string]*Allocation),
+		used:        make(map[string]map[string]bool),
+	}
+}
+
+func key(networkID, endpointID string) string {
+	return fmt.Sprintf("%s:%s", networkID, endpointID)
+}
+
+// Allocate reserves the next free address in cidr for the given endpoint
+// and returns it without a prefix (e.g. "10.99.0.2").
+func (m *Manager) Allocate(networkID, endpointID, cidr string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ip, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid infra_vnet_cidr %s: %w", cidr, err)
+	}
+
+	used := m.used[networkID]
+	if used == nil {
+		used = make(map[string]bool)
+		m.used[networkID] = used
+	}
+
+	// Walk the pool skipping the network address, the broadcast address, and
+	// the infra-vnet gateway (network+1) - not any address merely ending in
+	// ".1", which over-reserves for anything wider than a /24 (e.g. a /16
+	// would skip every x.x.x.1 in the range).
+	gateway := nextIP(ip.Mask(ipnet.Mask))
+	for candidate := gateway; ipnet.Contains(candidate); candidate = nextIP(candidate) {
+		addr := candidate.String()
+		if candidate.Equal(gateway) || used[addr] || isBroadcast(candidate, ipnet) {
+			continue
+		}
+		used[addr] = true
+		m.allocations[key(networkID, endpointID)] = &Allocation{
+			NetworkID:   networkID,
+			EndpointID:  endpointID,
+			InfraVNetIP: addr,
+			CIDR:        cidr,
+		}
+		return addr, nil
+	}
+
+	return "", fmt.Errorf("infra-vnet pool %s exhausted for network %s", cidr, networkID)
+}
+
+// Release frees a previously allocated address.
+func (m *Manager) Release(networkID, endpointID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	alloc, ok := m.allocations[key(networkID, endpointID)]
+	if !ok {
+		return
+	}
+	delete(m.allocations, key(networkID, endpointID))
+	if used := m.used[networkID]; used != nil {
+		delete(used, alloc.InfraVNetIP)
+	}
+}
+
+// Reserve records an already-allocated address (e.g. one loaded from the
+// store during Recover) without handing out a new one, so subsequent
+// Allocate calls don't collide with it.
+func (m *Manager) Reserve(networkID, endpointID, infraVNetIP, cidr string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	used := m.used[networkID]
+	if used == nil {
+		used = make(map[string]bool)
+		m.used[networkID] = used
+	}
+	used[infraVNetIP] = true
+	m.allocations[key(networkID, endpointID)] = &Allocation{
+		NetworkID:   networkID,
+		EndpointID:  endpointID,
+		InfraVNetIP: infraVNetIP,
+		CIDR:        cidr,
+	}
+}
+
+// ProgramRule installs an iptables SNAT rule in the host netns rewriting
+// egress from podAddress to infraVNetIP. It is idempotent: if the rule is
+// already present, iptables -C succeeds and we skip -A.
+func (m *Manager) ProgramRule(podAddress, infraVNetIP string) error {
+	podIP := strings.SplitN(podAddress, "/", 2)[0]
+
+	checkArgs := []string{"-t", "nat", "-C", "POSTROUTING", "-s", podIP, "-j", "SNAT", "--to-source", infraVNetIP}
+	if err := exec.Command("iptables", checkArgs...).Run(); err == nil {
+		m.logger.Debugf("SNAT rule for %s -> %s already present", podIP, infraVNetIP)
+		return nil
+	}
+
+	addArgs := []string{"-t", "nat", "-A", "POSTROUTING", "-s", podIP, "-j", "SNAT", "--to-source", infraVNetIP}
+	cmd := exec.Command("iptables", addArgs...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to program SNAT rule for %s -> %s: %w (output: %s)", podIP, infraVNetIP, err, string(output))
+	}
+
+	m.logger.Infof("Programmed host SNAT rule: %s -> %s", podIP, infraVNetIP)
+	return nil
+}
+
+// RemoveRule removes a previously programmed SNAT rule. It is safe to call
+// even if the rule is already gone.
+func (m *Manager) RemoveRule(podAddress, infraVNetIP string) error {
+	podIP := strings.SplitN(podAddress, "/", 2)[0]
+
+	args := []string{"-t", "nat", "-D", "POSTROUTING", "-s", podIP, "-j", "SNAT", "--to-source", infraVNetIP}
+	cmd := exec.Command("iptables", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if strings.Contains(string(output), "Bad rule") || strings.Contains(string(output), "does a matching rule exist") {
+			return nil
+		}
+		return fmt.Errorf("failed to remove SNAT rule for %s -> %s: %w (output: %s)", podIP, infraVNetIP, err, string(output))
+	}
+
+	m.logger.Infof("Removed host SNAT rule: %s -> %s", podIP, infraVNetIP)
+	return nil
+}
+
+// RuleExists reports whether a SNAT rule for podAddress -> infraVNetIP is
+// currently programmed, for use during Recover.
+func (m *Manager) RuleExists(podAddress, infraVNetIP string) bool {
+	podIP := strings.SplitN(podAddress, "/", 2)[0]
+	args := []string{"-t", "nat", "-C", "POSTROUTING", "-s", podIP, "-j", "SNAT", "--to-source", infraVNetIP}
+	return exec.Command("iptables", args...).Run() == nil
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+func isBroadcast(ip net.IP, ipnet *net.IPNet) bool {
+	broadcast := make(net.IP, len(ipnet.IP))
+	for i := range ipnet.IP {
+		broadcast[i] = ipnet.IP[i] | ^ipnet.Mask[i]
+	}
+	return ip.Equal(broadcast)
+}
@@ -0,0 +1,64 @@
+package snat
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocateSkipsGatewayAndNetworkAddress(t *testing.T) {
+	m := NewManager(logrus.New())
+
+	addr, err := m.Allocate("net1", "ep1", "10.99.0.0/24")
+	require.NoError(t, err)
+	assert.NotEqual(t, "10.99.0.0", addr)
+	assert.NotEqual(t, "10.99.0.1", addr)
+}
+
+func TestAllocateDoesNotReuseAddresses(t *testing.T) {
+	m := NewManager(logrus.New())
+
+	// /29 has several usable addresses; a /30 has only one (see TestAllocatePoolExhausted).
+	addr1, err := m.Allocate("net1", "ep1", "10.99.0.0/29")
+	require.NoError(t, err)
+
+	addr2, err := m.Allocate("net1", "ep2", "10.99.0.0/29")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, addr1, addr2)
+}
+
+func TestAllocatePoolExhausted(t *testing.T) {
+	m := NewManager(logrus.New())
+
+	// /30 has one usable non-gateway address (.2); .0 is network, .1 is gateway, .3 is broadcast.
+	_, err := m.Allocate("net1", "ep1", "10.99.0.0/30")
+	require.NoError(t, err)
+
+	_, err = m.Allocate("net1", "ep2", "10.99.0.0/30")
+	assert.Error(t, err)
+}
+
+func TestReleaseFreesAddressForReuse(t *testing.T) {
+	m := NewManager(logrus.New())
+
+	addr, err := m.Allocate("net1", "ep1", "10.99.0.0/30")
+	require.NoError(t, err)
+
+	m.Release("net1", "ep1")
+
+	addr2, err := m.Allocate("net1", "ep2", "10.99.0.0/30")
+	require.NoError(t, err)
+	assert.Equal(t, addr, addr2)
+}
+
+func TestReserveBlocksFutureAllocation(t *testing.T) {
+	m := NewManager(logrus.New())
+
+	m.Reserve("net1", "ep1", "10.99.0.2", "10.99.0.0/30")
+
+	_, err := m.Allocate("net1", "ep2", "10.99.0.0/30")
+	assert.Error(t, err, "the reserved address was the only usable one in the /30")
+}
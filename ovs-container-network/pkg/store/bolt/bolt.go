@@ -0,0 +1,189 @@
+// Package bolt implements kv.KV on an embedded BoltDB (bbolt) file. It's the
+// default backend for single-instance deployments: unlike pkg/store/file,
+// every Put/Delete/CAS/Txn commits inside a real bbolt transaction, so a
+// crash mid-write can't leave a key torn or a multi-key Txn half-applied.
+// Multi-instance HA deployments should still use the etcd or consul backend,
+// where Watch reflects every instance's writes, not just this one's.
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/kv"
+	bbolt "go.etcd.io/bbolt"
+)
+
+var bucketName = []byte("store")
+
+// KV stores every key as an entry in a single bucket of a bbolt database file.
+type KV struct {
+	db *bbolt.DB
+
+	subsMu sync.RWMutex
+	subs   map[chan kv.Event]string // channel -> prefix it was registered for
+}
+
+// New opens (creating if necessary) a bbolt database at path.
+func New(path string) (*KV, error) {
+	db, err := bbolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt database %s: %w", path, err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize bolt bucket in %s: %w", path, err)
+	}
+	return &KV{db: db, subs: make(map[chan kv.Event]string)}, nil
+}
+
+func (k *KV) Put(ctx context.Context, key string, value []byte) error {
+	if err := k.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketName).Put([]byte(key), value)
+	}); err != nil {
+		return fmt.Errorf("bolt: failed to put key %s: %w", key, err)
+	}
+	k.notify(kv.Event{Type: kv.EventPut, Key: key, Value: value})
+	return nil
+}
+
+func (k *KV) Get(ctx context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := k.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(bucketName).Get([]byte(key))
+		if v == nil {
+			return kv.ErrNotFound
+		}
+		value = append([]byte(nil), v...)
+		return nil
+	})
+	if err != nil {
+		if err == kv.ErrNotFound {
+			return nil, kv.ErrNotFound
+		}
+		return nil, fmt.Errorf("bolt: failed to get key %s: %w", key, err)
+	}
+	return value, nil
+}
+
+func (k *KV) Delete(ctx context.Context, key string) error {
+	if err := k.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketName).Delete([]byte(key))
+	}); err != nil {
+		return fmt.Errorf("bolt: failed to delete key %s: %w", key, err)
+	}
+	k.notify(kv.Event{Type: kv.EventDelete, Key: key})
+	return nil
+}
+
+// CAS runs entirely inside one bbolt write transaction, so it's atomic by
+// construction instead of needing the compare-and-retry loop the
+// etcd/consul backends use.
+func (k *KV) CAS(ctx context.Context, key string, modify func(current []byte, exists bool) ([]byte, error)) error {
+	var ev kv.Event
+	err := k.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		current := b.Get([]byte(key))
+		exists := current != nil
+		var currentCopy []byte
+		if exists {
+			currentCopy = append([]byte(nil), current...)
+		}
+
+		next, err := modify(currentCopy, exists)
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			ev = kv.Event{Type: kv.EventDelete, Key: key}
+			return b.Delete([]byte(key))
+		}
+		ev = kv.Event{Type: kv.EventPut, Key: key, Value: next}
+		return b.Put([]byte(key), next)
+	})
+	if err != nil {
+		return fmt.Errorf("bolt: failed to CAS key %s: %w", key, err)
+	}
+	k.notify(ev)
+	return nil
+}
+
+// Txn writes every key in puts inside one bbolt transaction: either all of
+// them land or, on error, none do.
+func (k *KV) Txn(ctx context.Context, puts map[string][]byte) error {
+	if err := k.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		for key, value := range puts {
+			if err := b.Put([]byte(key), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("bolt: failed to commit transaction: %w", err)
+	}
+	for key, value := range puts {
+		k.notify(kv.Event{Type: kv.EventPut, Key: key, Value: value})
+	}
+	return nil
+}
+
+func (k *KV) List(ctx context.Context, prefix string) (map[string][]byte, error) {
+	result := make(map[string][]byte)
+	err := k.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(bucketName).Cursor()
+		p := []byte(prefix)
+		for key, value := c.Seek(p); key != nil && strings.HasPrefix(string(key), prefix); key, value = c.Next() {
+			result[string(key)] = append([]byte(nil), value...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bolt: failed to list prefix %s: %w", prefix, err)
+	}
+	return result, nil
+}
+
+func (k *KV) Watch(ctx context.Context, prefix string) <-chan kv.Event {
+	ch := make(chan kv.Event, 16)
+	k.subsMu.Lock()
+	k.subs[ch] = prefix
+	k.subsMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		k.subsMu.Lock()
+		delete(k.subs, ch)
+		k.subsMu.Unlock()
+		close(ch)
+	}()
+
+	return ch
+}
+
+func (k *KV) Close() error {
+	if err := k.db.Close(); err != nil {
+		return fmt.Errorf("bolt: failed to close database: %w", err)
+	}
+	return nil
+}
+
+func (k *KV) notify(ev kv.Event) {
+	k.subsMu.RLock()
+	defer k.subsMu.RUnlock()
+	for ch, prefix := range k.subs {
+		if !strings.HasPrefix(ev.Key, prefix) {
+			continue
+		}
+		select {
+		case ch <- ev:
+		default:
+			// Slow consumer: drop rather than block writers.
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package bolt
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/kv"
+)
+
+func newTestKV(t *testing.T) *KV {
+	t.Helper()
+	tmpDir, err := ioutil.TempDir("", "bolt_kv_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpDir) })
+
+	k, err := New(filepath.Join(tmpDir, "store.db"))
+	if err != nil {
+		t.Fatalf("Failed to create bolt KV: %v", err)
+	}
+	t.Cleanup(func() { k.Close() })
+	return k
+}
+
+func TestPutGetDelete(t *testing.T) {
+	k := newTestKV(t)
+	ctx := context.Background()
+
+	if err := k.Put(ctx, "networks/net1", []byte(`{"id":"net1"}`)); err != nil {
+		t.Fatalf("Failed to put key: %v", err)
+	}
+
+	data, err := k.Get(ctx, "networks/net1")
+	if err != nil {
+		t.Fatalf("Failed to get key: %v", err)
+	}
+	if string(data) != `{"id":"net1"}` {
+		t.Errorf("Unexpected value: %s", data)
+	}
+
+	if err := k.Delete(ctx, "networks/net1"); err != nil {
+		t.Fatalf("Failed to delete key: %v", err)
+	}
+	if _, err := k.Get(ctx, "networks/net1"); err != kv.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestListPrefix(t *testing.T) {
+	k := newTestKV(t)
+	ctx := context.Background()
+
+	k.Put(ctx, "networks/net1", []byte("a"))
+	k.Put(ctx, "networks/net2", []byte("b"))
+	k.Put(ctx, "endpoints/net1:ep1", []byte("c"))
+
+	networks, err := k.List(ctx, "networks/")
+	if err != nil {
+		t.Fatalf("Failed to list networks: %v", err)
+	}
+	if len(networks) != 2 {
+		t.Errorf("Expected 2 networks, got %d", len(networks))
+	}
+}
+
+func TestCAS(t *testing.T) {
+	k := newTestKV(t)
+	ctx := context.Background()
+
+	// CAS on a missing key should see exists=false and create it.
+	err := k.CAS(ctx, "networks/net1", func(current []byte, exists bool) ([]byte, error) {
+		if exists {
+			t.Fatal("Expected key not to exist yet")
+		}
+		return []byte("v1"), nil
+	})
+	if err != nil {
+		t.Fatalf("CAS create failed: %v", err)
+	}
+
+	// CAS on an existing key should see its current value and can update it.
+	err = k.CAS(ctx, "networks/net1", func(current []byte, exists bool) ([]byte, error) {
+		if !exists || string(current) != "v1" {
+			t.Fatalf("Expected current value v1, got %q (exists=%v)", current, exists)
+		}
+		return []byte("v2"), nil
+	})
+	if err != nil {
+		t.Fatalf("CAS update failed: %v", err)
+	}
+
+	data, err := k.Get(ctx, "networks/net1")
+	if err != nil || string(data) != "v2" {
+		t.Fatalf("Expected v2 after CAS update, got %q, err %v", data, err)
+	}
+
+	// A nil result deletes the key.
+	if err := k.CAS(ctx, "networks/net1", func([]byte, bool) ([]byte, error) { return nil, nil }); err != nil {
+		t.Fatalf("CAS delete failed: %v", err)
+	}
+	if _, err := k.Get(ctx, "networks/net1"); err != kv.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after CAS delete, got %v", err)
+	}
+}
+
+func TestTxn(t *testing.T) {
+	k := newTestKV(t)
+	ctx := context.Background()
+
+	puts := map[string][]byte{
+		"networks/net1":      []byte("a"),
+		"endpoints/net1:ep1": []byte("b"),
+		"endpoints/net1:ep2": []byte("c"),
+	}
+	if err := k.Txn(ctx, puts); err != nil {
+		t.Fatalf("Txn failed: %v", err)
+	}
+
+	for key, want := range puts {
+		got, err := k.Get(ctx, key)
+		if err != nil || string(got) != string(want) {
+			t.Errorf("key %s: got %q, err %v; want %q", key, got, err, want)
+		}
+	}
+}
+
+func TestPersistsAcrossInstances(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "bolt_kv_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	path := filepath.Join(tmpDir, "store.db")
+
+	k1, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k1.Put(context.Background(), "networks/net1", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := k1.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	k2, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer k2.Close()
+
+	data, err := k2.Get(context.Background(), "networks/net1")
+	if err != nil {
+		t.Fatalf("Failed to recover key from disk: %v", err)
+	}
+	if string(data) != "data" {
+		t.Errorf("Unexpected recovered value: %s", data)
+	}
+}
@@ -0,0 +1,192 @@
+// Package consul implements kv.KV against Consul's KV store, giving the
+// plugin a shared store so multiple driver instances can run at once for
+// HA instead of each keeping independent local state.
+package consul
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/kv"
+)
+
+// KV stores keys as-is using Consul's own hierarchy, since Consul treats
+// "/" in a key as an ordinary byte, not a directory separator.
+type KV struct {
+	client *api.Client
+}
+
+// New connects to the Consul agent at addr (host:port).
+func New(addr string) (*KV, error) {
+	cfg := api.DefaultConfig()
+	if addr != "" {
+		cfg.Address = addr
+	}
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to consul at %s: %w", addr, err)
+	}
+	return &KV{client: client}, nil
+}
+
+func (k *KV) Put(ctx context.Context, key string, value []byte) error {
+	pair := &api.KVPair{Key: key, Value: value}
+	if _, err := k.client.KV().Put(pair, nil); err != nil {
+		return fmt.Errorf("consul: failed to put key %s: %w", key, err)
+	}
+	return nil
+}
+
+func (k *KV) Get(ctx context.Context, key string) ([]byte, error) {
+	pair, _, err := k.client.KV().Get(key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("consul: failed to get key %s: %w", key, err)
+	}
+	if pair == nil {
+		return nil, kv.ErrNotFound
+	}
+	return pair.Value, nil
+}
+
+func (k *KV) Delete(ctx context.Context, key string) error {
+	if _, err := k.client.KV().Delete(key, nil); err != nil {
+		return fmt.Errorf("consul: failed to delete key %s: %w", key, err)
+	}
+	return nil
+}
+
+// CAS retries modify against a fresh read each time Consul's CAS Put
+// reports the key's ModifyIndex moved between the read and the write, so
+// concurrent driver instances sharing this backend can't clobber each
+// other's update.
+func (k *KV) CAS(ctx context.Context, key string, modify func(current []byte, exists bool) ([]byte, error)) error {
+	for {
+		pair, _, err := k.client.KV().Get(key, nil)
+		if err != nil {
+			return fmt.Errorf("consul: failed to get key %s for CAS: %w", key, err)
+		}
+
+		var current []byte
+		var modifyIndex uint64
+		exists := pair != nil
+		if exists {
+			current = pair.Value
+			modifyIndex = pair.ModifyIndex
+		}
+
+		next, err := modify(current, exists)
+		if err != nil {
+			return err
+		}
+
+		if next == nil {
+			ok, _, err := k.client.KV().DeleteCAS(&api.KVPair{Key: key, ModifyIndex: modifyIndex}, nil)
+			if err != nil {
+				return fmt.Errorf("consul: failed to CAS-delete key %s: %w", key, err)
+			}
+			if ok {
+				return nil
+			}
+			continue
+		}
+
+		ok, _, err := k.client.KV().CAS(&api.KVPair{Key: key, Value: next, ModifyIndex: modifyIndex}, nil)
+		if err != nil {
+			return fmt.Errorf("consul: failed to CAS key %s: %w", key, err)
+		}
+		if ok {
+			return nil
+		}
+		// Another writer raced us between Get and CAS; retry against the new value.
+	}
+}
+
+// Txn applies every put in a single Consul transaction, so readers never
+// see a partial write across the batch.
+func (k *KV) Txn(ctx context.Context, puts map[string][]byte) error {
+	ops := make(api.KVTxnOps, 0, len(puts))
+	for key, value := range puts {
+		ops = append(ops, &api.KVTxnOp{Verb: api.KVSet, Key: key, Value: value})
+	}
+	ok, resp, _, err := k.client.KV().Txn(ops, nil)
+	if err != nil {
+		return fmt.Errorf("consul: failed to commit transaction: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("consul: transaction failed: %v", resp.Errors)
+	}
+	return nil
+}
+
+func (k *KV) List(ctx context.Context, prefix string) (map[string][]byte, error) {
+	pairs, _, err := k.client.KV().List(prefix, nil)
+	if err != nil {
+		return nil, fmt.Errorf("consul: failed to list prefix %s: %w", prefix, err)
+	}
+	result := make(map[string][]byte, len(pairs))
+	for _, pair := range pairs {
+		result[pair.Key] = pair.Value
+	}
+	return result, nil
+}
+
+// Watch polls Consul's blocking query API, the idiomatic way to observe KV
+// changes there: each iteration blocks server-side until the prefix's
+// ModifyIndex advances past the last one seen, then diffs the snapshot to
+// synthesize put/delete events.
+func (k *KV) Watch(ctx context.Context, prefix string) <-chan kv.Event {
+	out := make(chan kv.Event, 16)
+
+	go func() {
+		defer close(out)
+
+		seen := make(map[string][]byte)
+		var lastIndex uint64
+
+		for {
+			pairs, meta, err := k.client.KV().List(prefix, (&api.QueryOptions{
+				WaitIndex: lastIndex,
+			}).WithContext(ctx))
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				continue
+			}
+			lastIndex = meta.LastIndex
+
+			current := make(map[string][]byte, len(pairs))
+			for _, pair := range pairs {
+				current[pair.Key] = pair.Value
+				if old, ok := seen[pair.Key]; !ok || string(old) != string(pair.Value) {
+					select {
+					case out <- kv.Event{Type: kv.EventPut, Key: pair.Key, Value: pair.Value}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			for key := range seen {
+				if _, ok := current[key]; !ok {
+					select {
+					case out <- kv.Event{Type: kv.EventDelete, Key: key}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			seen = current
+
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+func (k *KV) Close() error {
+	return nil
+}
@@ -0,0 +1,160 @@
+// Package etcd implements kv.KV against an etcd cluster, giving the plugin
+// a shared, strongly-consistent store so multiple driver instances can run
+// at once for HA instead of each keeping independent local state.
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/kv"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const dialTimeout = 5 * time.Second
+
+// KV stores keys as-is (flat strings) using etcd's own hierarchy, since
+// etcd has no directory concept and treats "/" as an ordinary byte.
+type KV struct {
+	client *clientv3.Client
+}
+
+// New connects to the etcd cluster at endpoints.
+func New(endpoints []string) (*KV, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: dialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to etcd at %v: %w", endpoints, err)
+	}
+	return &KV{client: client}, nil
+}
+
+func (k *KV) Put(ctx context.Context, key string, value []byte) error {
+	if _, err := k.client.Put(ctx, key, string(value)); err != nil {
+		return fmt.Errorf("etcd: failed to put key %s: %w", key, err)
+	}
+	return nil
+}
+
+func (k *KV) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := k.client.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("etcd: failed to get key %s: %w", key, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, kv.ErrNotFound
+	}
+	return resp.Kvs[0].Value, nil
+}
+
+func (k *KV) Delete(ctx context.Context, key string) error {
+	if _, err := k.client.Delete(ctx, key); err != nil {
+		return fmt.Errorf("etcd: failed to delete key %s: %w", key, err)
+	}
+	return nil
+}
+
+// CAS retries modify against a fresh read each time etcd reports the key
+// changed between the read and the write, so concurrent driver instances
+// sharing this backend can't clobber each other's update.
+func (k *KV) CAS(ctx context.Context, key string, modify func(current []byte, exists bool) ([]byte, error)) error {
+	for {
+		getResp, err := k.client.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("etcd: failed to get key %s for CAS: %w", key, err)
+		}
+
+		var current []byte
+		var modRev int64
+		exists := len(getResp.Kvs) > 0
+		if exists {
+			current = getResp.Kvs[0].Value
+			modRev = getResp.Kvs[0].ModRevision
+		}
+
+		next, err := modify(current, exists)
+		if err != nil {
+			return err
+		}
+
+		var op clientv3.Op
+		if next == nil {
+			op = clientv3.OpDelete(key)
+		} else {
+			op = clientv3.OpPut(key, string(next))
+		}
+
+		txnResp, err := k.client.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", modRev)).
+			Then(op).
+			Commit()
+		if err != nil {
+			return fmt.Errorf("etcd: failed to CAS key %s: %w", key, err)
+		}
+		if txnResp.Succeeded {
+			return nil
+		}
+		// Another writer raced us between Get and Txn; retry against the new value.
+	}
+}
+
+// Txn applies every put in a single etcd transaction, so readers never see
+// a partial write across the batch.
+func (k *KV) Txn(ctx context.Context, puts map[string][]byte) error {
+	ops := make([]clientv3.Op, 0, len(puts))
+	for key, value := range puts {
+		ops = append(ops, clientv3.OpPut(key, string(value)))
+	}
+	if _, err := k.client.Txn(ctx).Then(ops...).Commit(); err != nil {
+		return fmt.Errorf("etcd: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (k *KV) List(ctx context.Context, prefix string) (map[string][]byte, error) {
+	resp, err := k.client.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("etcd: failed to list prefix %s: %w", prefix, err)
+	}
+	result := make(map[string][]byte, len(resp.Kvs))
+	for _, item := range resp.Kvs {
+		result[string(item.Key)] = item.Value
+	}
+	return result, nil
+}
+
+func (k *KV) Watch(ctx context.Context, prefix string) <-chan kv.Event {
+	out := make(chan kv.Event, 16)
+	watchCh := k.client.Watch(ctx, prefix, clientv3.WithPrefix())
+
+	go func() {
+		defer close(out)
+		for resp := range watchCh {
+			for _, ev := range resp.Events {
+				event := kv.Event{Key: string(ev.Kv.Key), Value: ev.Kv.Value}
+				if ev.Type == clientv3.EventTypeDelete {
+					event.Type = kv.EventDelete
+				} else {
+					event.Type = kv.EventPut
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+func (k *KV) Close() error {
+	if err := k.client.Close(); err != nil {
+		return fmt.Errorf("etcd: failed to close client: %w", err)
+	}
+	return nil
+}
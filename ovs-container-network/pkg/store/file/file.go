@@ -0,0 +1,212 @@
+// Package file implements kv.KV as one JSON-bearing file per key under a
+// data directory. It's the pre-bolt single-instance backend, kept for
+// compatibility (pkg/store/bolt is the default), and requires no external
+// service; it cannot observe writes made by
+// another process, so Watch only reports changes made through the same KV
+// instance. Multi-instance HA deployments should use the etcd or consul
+// backend instead, where Watch reflects every instance's writes.
+package file
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/kv"
+)
+
+// KV stores one file per key under dir.
+type KV struct {
+	dir string
+
+	// dataMu serializes CAS/Txn against each other and against plain
+	// Put/Delete calls, so a CAS's read-modify-write can't race a
+	// concurrent writer within this process.
+	dataMu sync.Mutex
+
+	subsMu sync.RWMutex
+	subs   map[chan kv.Event]string // channel -> prefix it was registered for
+}
+
+// New creates a file-backed KV rooted at dir, creating it if necessary.
+func New(dir string) (*KV, error) {
+	if dir == "" {
+		dir = "/data"
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	return &KV{dir: dir, subs: make(map[chan kv.Event]string)}, nil
+}
+
+// filename maps a key to a path under dir, path-escaping it (so the "/" the
+// store package uses as a prefix separator becomes "%2F") so each key stays
+// a flat file.
+func (k *KV) filename(key string) string {
+	return filepath.Join(k.dir, url.PathEscape(key)+".json")
+}
+
+func (k *KV) Put(ctx context.Context, key string, value []byte) error {
+	k.dataMu.Lock()
+	defer k.dataMu.Unlock()
+	return k.putLocked(key, value)
+}
+
+func (k *KV) Get(ctx context.Context, key string) ([]byte, error) {
+	return k.getLocked(key)
+}
+
+func (k *KV) Delete(ctx context.Context, key string) error {
+	k.dataMu.Lock()
+	defer k.dataMu.Unlock()
+	return k.deleteLocked(key)
+}
+
+// CAS is safe against concurrent callers within this process (dataMu
+// serializes them) but, like the rest of this backend, can't see writes
+// made by another process - multi-instance HA deployments should use etcd
+// or consul.
+func (k *KV) CAS(ctx context.Context, key string, modify func(current []byte, exists bool) ([]byte, error)) error {
+	k.dataMu.Lock()
+	defer k.dataMu.Unlock()
+
+	current, err := k.getLocked(key)
+	exists := true
+	if err == kv.ErrNotFound {
+		exists, err = false, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	next, err := modify(current, exists)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		return k.deleteLocked(key)
+	}
+	return k.putLocked(key, next)
+}
+
+// Txn has no cross-file atomicity to offer (each key is its own file) - it
+// writes every key in order, same as calling Put in a loop.
+func (k *KV) Txn(ctx context.Context, puts map[string][]byte) error {
+	k.dataMu.Lock()
+	defer k.dataMu.Unlock()
+	for key, value := range puts {
+		if err := k.putLocked(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putLocked writes value to a temp file and renames it into place, so a
+// reader never observes a torn write; dataMu must already be held.
+func (k *KV) putLocked(key string, value []byte) error {
+	path := k.filename(key)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, value, 0644); err != nil {
+		return fmt.Errorf("failed to write key %s: %w", key, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to commit key %s: %w", key, err)
+	}
+	k.notify(kv.Event{Type: kv.EventPut, Key: key, Value: value})
+	return nil
+}
+
+func (k *KV) getLocked(key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(k.filename(key))
+	if os.IsNotExist(err) {
+		return nil, kv.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key %s: %w", key, err)
+	}
+	return data, nil
+}
+
+func (k *KV) deleteLocked(key string) error {
+	if err := os.Remove(k.filename(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete key %s: %w", key, err)
+	}
+	k.notify(kv.Event{Type: kv.EventDelete, Key: key})
+	return nil
+}
+
+func (k *KV) List(ctx context.Context, prefix string) (map[string][]byte, error) {
+	entries, err := ioutil.ReadDir(k.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data directory: %w", err)
+	}
+
+	result := make(map[string][]byte)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if filepath.Ext(name) != ".json" {
+			continue
+		}
+		key, err := url.PathUnescape(name[:len(name)-len(".json")])
+		if err != nil {
+			continue
+		}
+		if !hasPrefix(key, prefix) {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(k.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", key, err)
+		}
+		result[key] = data
+	}
+	return result, nil
+}
+
+func (k *KV) Watch(ctx context.Context, prefix string) <-chan kv.Event {
+	ch := make(chan kv.Event, 16)
+	k.subsMu.Lock()
+	k.subs[ch] = prefix
+	k.subsMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		k.subsMu.Lock()
+		delete(k.subs, ch)
+		k.subsMu.Unlock()
+		close(ch)
+	}()
+
+	return ch
+}
+
+func (k *KV) Close() error {
+	return nil
+}
+
+func (k *KV) notify(ev kv.Event) {
+	k.subsMu.RLock()
+	defer k.subsMu.RUnlock()
+	for ch, prefix := range k.subs {
+		if !hasPrefix(ev.Key, prefix) {
+			continue
+		}
+		select {
+		case ch <- ev:
+		default:
+			// Slow consumer: drop rather than block writers.
+		}
+	}
+}
+
+func hasPrefix(key, prefix string) bool {
+	return len(key) >= len(prefix) && key[:len(prefix)] == prefix
+}
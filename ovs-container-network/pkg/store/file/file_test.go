@@ -0,0 +1,175 @@
+package file
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/kv"
+)
+
+func TestPutGetDelete(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "file_kv_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	k, err := New(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create file KV: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := k.Put(ctx, "networks/net1", []byte(`{"id":"net1"}`)); err != nil {
+		t.Fatalf("Failed to put key: %v", err)
+	}
+
+	data, err := k.Get(ctx, "networks/net1")
+	if err != nil {
+		t.Fatalf("Failed to get key: %v", err)
+	}
+	if string(data) != `{"id":"net1"}` {
+		t.Errorf("Unexpected value: %s", data)
+	}
+
+	if err := k.Delete(ctx, "networks/net1"); err != nil {
+		t.Fatalf("Failed to delete key: %v", err)
+	}
+	if _, err := k.Get(ctx, "networks/net1"); err != kv.ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestListPrefix(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "file_kv_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	k, err := New(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	k.Put(ctx, "networks/net1", []byte("a"))
+	k.Put(ctx, "networks/net2", []byte("b"))
+	k.Put(ctx, "endpoints/net1:ep1", []byte("c"))
+
+	networks, err := k.List(ctx, "networks/")
+	if err != nil {
+		t.Fatalf("Failed to list networks: %v", err)
+	}
+	if len(networks) != 2 {
+		t.Errorf("Expected 2 networks, got %d", len(networks))
+	}
+
+	endpoints, err := k.List(ctx, "endpoints/")
+	if err != nil {
+		t.Fatalf("Failed to list endpoints: %v", err)
+	}
+	if len(endpoints) != 1 {
+		t.Errorf("Expected 1 endpoint, got %d", len(endpoints))
+	}
+}
+
+func TestPersistsAcrossInstances(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "file_kv_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	k1, err := New(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k1.Put(context.Background(), "networks/net1", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+
+	k2, err := New(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := k2.Get(context.Background(), "networks/net1")
+	if err != nil {
+		t.Fatalf("Failed to recover key from disk: %v", err)
+	}
+	if string(data) != "data" {
+		t.Errorf("Unexpected recovered value: %s", data)
+	}
+}
+
+func TestCAS(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "file_kv_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	k, err := New(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	err = k.CAS(ctx, "networks/net1", func(current []byte, exists bool) ([]byte, error) {
+		if exists {
+			t.Fatal("Expected key not to exist yet")
+		}
+		return []byte("v1"), nil
+	})
+	if err != nil {
+		t.Fatalf("CAS create failed: %v", err)
+	}
+
+	err = k.CAS(ctx, "networks/net1", func(current []byte, exists bool) ([]byte, error) {
+		if !exists || string(current) != "v1" {
+			t.Fatalf("Expected current value v1, got %q (exists=%v)", current, exists)
+		}
+		return []byte("v2"), nil
+	})
+	if err != nil {
+		t.Fatalf("CAS update failed: %v", err)
+	}
+
+	data, err := k.Get(ctx, "networks/net1")
+	if err != nil || string(data) != "v2" {
+		t.Fatalf("Expected v2 after CAS update, got %q, err %v", data, err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "file_kv_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	k, err := New(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := k.Watch(ctx, "networks/")
+	if err := k.Put(ctx, "networks/net1", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Type != kv.EventPut || ev.Key != "networks/net1" {
+			t.Errorf("Unexpected event: %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for watch event")
+	}
+}
@@ -0,0 +1,68 @@
+// Package kv defines the minimal key-value contract the store package
+// builds network/endpoint persistence on top of, so the same persistence
+// and Watch logic in pkg/store runs unmodified regardless of whether the
+// backing service is the local filesystem, etcd, or Consul.
+package kv
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by Get when the key does not exist.
+var ErrNotFound = errors.New("kv: key not found")
+
+// EventType identifies what happened to a watched key.
+type EventType int
+
+const (
+	// EventPut fires when a key is created or overwritten.
+	EventPut EventType = iota
+	// EventDelete fires when a key is removed.
+	EventDelete
+)
+
+func (t EventType) String() string {
+	if t == EventDelete {
+		return "delete"
+	}
+	return "put"
+}
+
+// Event describes a single change to a key under a watched prefix.
+type Event struct {
+	Type  EventType
+	Key   string
+	Value []byte
+}
+
+// KV is the contract each backend (file, bolt, etcd, consul) implements.
+// Keys are flat strings; every backend stores them as-is, with "/" an
+// ordinary byte rather than a path separator.
+type KV interface {
+	// Put creates or overwrites key with value.
+	Put(ctx context.Context, key string, value []byte) error
+	// Get returns the value stored at key, or ErrNotFound if it doesn't exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes key. It does not error if key doesn't exist.
+	Delete(ctx context.Context, key string) error
+	// List returns every key-value pair whose key starts with prefix.
+	List(ctx context.Context, prefix string) (map[string][]byte, error)
+	// CAS atomically reads the current value at key (nil, false if it
+	// doesn't exist), passes it to modify, and writes the result back as a
+	// single operation - so two store instances sharing a backend can't
+	// race each other into clobbering the same key. modify may be called
+	// more than once if the backend has to retry around a concurrent writer.
+	// A nil result from modify deletes the key instead of writing it.
+	CAS(ctx context.Context, key string, modify func(current []byte, exists bool) ([]byte, error)) error
+	// Txn writes every key in puts as a single operation: backends that can
+	// guarantee atomicity (bolt, etcd, consul) apply all of them or none;
+	// the file backend, which has no native multi-key transaction, applies
+	// them in order as a best effort.
+	Txn(ctx context.Context, puts map[string][]byte) error
+	// Watch streams Put/Delete events for keys under prefix until ctx is
+	// cancelled, at which point the returned channel is closed.
+	Watch(ctx context.Context, prefix string) <-chan Event
+	// Close releases any connections or handles held by the backend.
+	Close() error
+}
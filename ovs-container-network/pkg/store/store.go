@@ -1,221 +1,962 @@
+// Package store persists plugin state - network/endpoint configuration and
+// the most recent startup reconciliation report - behind a Store interface
+// backed by a pluggable kv.KV. The embedded bolt backend (pkg/store/bolt) is
+// the default for single-instance deployments, crash-atomic without relying
+// on the older file backend's (pkg/store/file) rename tricks; the etcd and
+// consul backends (pkg/store/etcd, pkg/store/consul) share state across a
+// cluster of driver instances for HA. New/NewStoreWithBackend select among
+// them from a URL.
 package store
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"net/url"
+	"strings"
 	"sync"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/bolt"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/consul"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/etcd"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/file"
+	"github.com/ovs-container-lab/ovs-container-network/pkg/store/kv"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	networkPrefix     = "networks/"
+	endpointPrefix    = "endpoints/"
+	finalizerPrefix   = "finalizers/"
+	eipPrefix         = "eips/"
+	transitPortPrefix = "transit_ports/"
+	policyPrefix      = "policies/"
+	lbPrefix          = "load_balancers/"
 )
 
 // NetworkInfo stores persistent network configuration
 type NetworkInfo struct {
-	ID        string            `json:"id"`
-	Name      string            `json:"name"`
-	Bridge    string            `json:"bridge"`
-	VLAN      int               `json:"vlan,omitempty"`
-	TenantID  string            `json:"tenant_id,omitempty"`
-	OVNSwitch string            `json:"ovn_switch,omitempty"`
-	OVNRouter string            `json:"ovn_router,omitempty"`
-	Options   map[string]string `json:"options"`
-	IPAMData  json.RawMessage   `json:"ipam_data"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Bridge           string            `json:"bridge"`
+	VLAN             int               `json:"vlan,omitempty"`
+	TenantID         string            `json:"tenant_id,omitempty"`
+	OVNSwitch        string            `json:"ovn_switch,omitempty"`
+	OVNRouter        string            `json:"ovn_router,omitempty"`
+	EnableSNATOnHost bool              `json:"enable_snat_on_host,omitempty"`
+	InfraVNetCIDR    string            `json:"infra_vnet_cidr,omitempty"`
+	Mode             string            `json:"mode,omitempty"`
+	Parent           string            `json:"parent,omitempty"`
+	Options          map[string]string `json:"options"`
+	IPAMData         json.RawMessage   `json:"ipam_data"`
 }
 
 // EndpointInfo stores persistent endpoint configuration
 type EndpointInfo struct {
-	ID          string `json:"id"`
+	ID                  string               `json:"id"`
+	NetworkID           string               `json:"network_id"`
+	EndpointID          string               `json:"endpoint_id"`
+	ContainerID         string               `json:"container_id"`
+	VethName            string               `json:"veth_name"`
+	PortName            string               `json:"port_name,omitempty"`
+	SandboxKey          string               `json:"sandbox_key,omitempty"`
+	IPAddress           string               `json:"ip_address"`
+	IPv6Address         string               `json:"ipv6_address,omitempty"`
+	MACAddress          string               `json:"mac_address"`
+	Gateway             string               `json:"gateway"`
+	OVNPort             string               `json:"ovn_port,omitempty"`
+	SNATAddress         string               `json:"snat_address,omitempty"`
+	EIPID               string               `json:"eip_id,omitempty"`
+	PortBindings        []PortBinding        `json:"port_bindings,omitempty"`
+	SecondaryInterfaces []SecondaryInterface `json:"secondary_interfaces,omitempty"`
+	Status              EndpointStatus       `json:"status,omitempty"`
+}
+
+// SecondaryInterface persists one additional NIC of a multi-homed endpoint,
+// mirroring types.SecondaryInterface the same way the rest of this struct
+// duplicates rather than imports the in-memory Endpoint's shape.
+type SecondaryInterface struct {
+	IfName      string `json:"if_name"`
 	NetworkID   string `json:"network_id"`
-	EndpointID  string `json:"endpoint_id"`
-	ContainerID string `json:"container_id"`
-	VethName    string `json:"veth_name"`
-	IPAddress   string `json:"ip_address"`
-	MACAddress  string `json:"mac_address"`
-	Gateway     string `json:"gateway"`
+	MacAddress  string `json:"mac_address,omitempty"`
+	IPv4Address string `json:"ipv4_address,omitempty"`
+	VethName    string `json:"veth_name,omitempty"`
+	PortName    string `json:"port_name,omitempty"`
 	OVNPort     string `json:"ovn_port,omitempty"`
 }
 
-// Store manages persistent plugin state
-type Store struct {
-	dataDir   string
-	mu        sync.RWMutex
-	networks  map[string]*NetworkInfo
-	endpoints map[string]*EndpointInfo
+// EndpointStatus is a kube-ovn-style summary of an endpoint's address
+// families, recorded alongside IPAddress/IPv6Address so operators inspecting
+// persisted state can see at a glance which stacks are configured without
+// parsing the addresses themselves.
+type EndpointStatus struct {
+	V4Ip  string `json:"v4Ip,omitempty"`
+	V6Ip  string `json:"v6Ip,omitempty"`
+	Ready bool   `json:"ready"`
+}
+
+// PortBinding records one published container port - Docker's
+// "-p hostPort:containerPort/proto", optionally a range via HostPortEnd -
+// modeled on libnetwork's types.PortBinding/types.TransportPort. LBUUID is
+// the OVN Load_Balancer row backing it, so Leave/DeleteEndpoint can clean
+// up the VIP without having to recompute it.
+type PortBinding struct {
+	Proto         string `json:"proto"`
+	ContainerPort uint16 `json:"container_port"`
+	HostIP        string `json:"host_ip,omitempty"`
+	HostPort      uint16 `json:"host_port"`
+	HostPortEnd   uint16 `json:"host_port_end,omitempty"`
+	LBUUID        string `json:"lb_uuid,omitempty"`
+}
+
+// RecoveryReport summarizes the result of reconciling persisted state against
+// live OVS/netlink state on startup: how many endpoints matched what was
+// persisted, how many needed their OVS port re-added, and how many orphaned
+// OVS ports (with no matching endpoint) were garbage-collected.
+type RecoveryReport struct {
+	Healthy  int `json:"healthy"`
+	Repaired int `json:"repaired"`
+	Orphaned int `json:"orphaned"`
+
+	// ActiveEndpoints lists the endpoints the host scan found still have a
+	// live veth, keyed by endpoint ID - this package's equivalent of
+	// libnetwork's OptionActiveSandboxes, so Driver.Join can tell a
+	// just-recovered endpoint apart from a genuinely new one.
+	ActiveEndpoints map[string]EndpointRuntimeState `json:"active_endpoints,omitempty"`
+	// OrphanedVeths names host veths/OVS ports that were garbage-collected
+	// because no persisted endpoint claimed them.
+	OrphanedVeths []string `json:"orphaned_veths,omitempty"`
+	// MissingVeths names endpoints the store has on record whose veth no
+	// longer exists on the host, left for Docker to recreate on next Join.
+	MissingVeths []string `json:"missing_veths,omitempty"`
+}
+
+// EndpointRuntimeState is the host-side state recoverState discovers for one
+// endpoint during its startup scan - just enough for a caller to confirm the
+// endpoint is still alive without re-reading the full EndpointInfo.
+type EndpointRuntimeState struct {
+	NetworkID  string `json:"network_id"`
+	EndpointID string `json:"endpoint_id"`
+	VethName   string `json:"veth_name"`
+	SandboxKey string `json:"sandbox_key,omitempty"`
+}
+
+// FinalizerKind identifies the kind of shared OVN resource a Finalizer
+// tracks, ported from ovn4nfv-k8s-plugin's finalizer pattern.
+type FinalizerKind string
+
+const (
+	FinalizerSwitch FinalizerKind = "switch"
+	FinalizerRouter FinalizerKind = "router"
+	FinalizerDHCP   FinalizerKind = "dhcp_options"
+)
+
+// Finalizer records which Docker network and/or endpoint IDs are still
+// using a shared OVN resource (a logical switch/router name, or a
+// DHCP_Options row UUID). The resource is only safe to delete once Owners
+// is empty - CreateNetwork/CreateEndpoint add an owner via AddFinalizer,
+// DeleteNetwork/DeleteEndpoint remove one via RemoveFinalizer, and whoever
+// removes the last owner is responsible for actually deleting the OVN
+// resource.
+type Finalizer struct {
+	Kind     FinalizerKind   `json:"kind"`
+	Resource string          `json:"resource"`
+	Owners   map[string]bool `json:"owners"`
+}
+
+// TransitPortInfo stores one VPC router's persistent allocation on a
+// shared transit network - the address, MAC, and VPC subnet set
+// pkg/ipam.TransitAllocator hands out, so a plugin restart recovers the
+// same assignment instead of connectToTransitNetwork picking a new one.
+type TransitPortInfo struct {
+	ID               string   `json:"id"` // transitNetworkID:router
+	TransitNetworkID string   `json:"transit_network_id"`
+	Router           string   `json:"router"`
+	IP               string   `json:"ip"`
+	MAC              string   `json:"mac"`
+	Subnets          []string `json:"subnets,omitempty"`
+}
+
+// EIPInfo stores a persistent elastic/floating IP allocation - Kube-OVN's
+// ovn-eip CRD shape (v4Ip/v6Ip/macAddress/ready/conditions), ported here as
+// plugin-managed state rather than a CRD since this driver has no API
+// server of its own. Ready tracks whether the owning endpoint is currently
+// joined: DeleteEndpoint's safety-net path and Leave flip it false without
+// freeing the allocation, so a restarted container gets the same address
+// back; only an explicit delete (the HTTP /eip/{id} endpoint, or a fresh
+// CreateEndpoint on a different endpoint) frees it.
+type EIPInfo struct {
+	ID         string   `json:"id"`
+	NetworkID  string   `json:"network_id"`
+	EndpointID string   `json:"endpoint_id,omitempty"`
+	CIDR       string   `json:"cidr"`
+	V4Ip       string   `json:"v4Ip,omitempty"`
+	V6Ip       string   `json:"v6Ip,omitempty"`
+	MacAddress string   `json:"macAddress,omitempty"`
+	Ready      bool     `json:"ready"`
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// PolicyInfo persists the compiled hash of one security group's ACL policy
+// (see pkg/ovn's policy compiler), keyed by networkID:securityGroup, so a
+// restarted plugin can tell whether a network's ovn.acl.ingress/egress
+// options changed since the Port_Group's ACLs were last programmed instead
+// of reprogramming them unconditionally every time.
+type PolicyInfo struct {
+	ID            string `json:"id"` // networkID:securityGroup
+	NetworkID     string `json:"network_id"`
+	SecurityGroup string `json:"security_group"`
+	Hash          string `json:"hash"`
+}
+
+// LBInfo persists one "ovn.lb.<name>" load balancer declared on a network:
+// its OVN Load_Balancer row UUID, and the current backend set behind each
+// VIP, so the Driver can add/remove a joining/leaving endpoint's address
+// from the right VIP without re-deriving the whole backend list from
+// scratch on every Join/Leave.
+type LBInfo struct {
+	ID        string              `json:"id"` // networkID:lbName
+	NetworkID string              `json:"network_id"`
+	Name      string              `json:"name"`
+	UUID      string              `json:"uuid"`     // OVN Load_Balancer row UUID
+	Protocol  string              `json:"protocol"` // "tcp", "udp", or "" for OVN's default
+	VIPs      map[string][]string `json:"vips"`     // "ip:port" -> backend "ip:port" list
+}
+
+// EventType mirrors kv.EventType for store-level watchers, so callers don't
+// need to import pkg/store/kv just to compare event types.
+type EventType = kv.EventType
+
+const (
+	EventPut    = kv.EventPut
+	EventDelete = kv.EventDelete
+)
+
+// Event reports a change to a network or endpoint, made by this instance or,
+// for the etcd/consul backends, any other instance sharing the same store.
+// EndpointID is empty for events about a network itself.
+type Event struct {
+	Type       EventType
+	NetworkID  string
+	EndpointID string
+}
+
+// Store persists plugin state. Implementations are backed by a kv.KV -
+// pkg/store/bolt (the default) or the legacy pkg/store/file for
+// single-instance deployments, pkg/store/etcd or pkg/store/consul for
+// clustered HA - selected at startup by New.
+type Store interface {
+	SaveNetwork(network *NetworkInfo) error
+	GetNetwork(networkID string) (*NetworkInfo, error)
+	DeleteNetwork(networkID string) error
+	ListNetworks() []*NetworkInfo
+
+	SaveEndpoint(endpoint *EndpointInfo) error
+	GetEndpoint(networkID, endpointID string) (*EndpointInfo, error)
+	DeleteEndpoint(networkID, endpointID string) error
+	ListEndpoints() []*EndpointInfo
+
+	SaveEIP(eip *EIPInfo) error
+	GetEIP(eipID string) (*EIPInfo, error)
+	DeleteEIP(eipID string) error
+	ListEIPs() []*EIPInfo
+
+	SaveTransitPort(port *TransitPortInfo) error
+	GetTransitPort(id string) (*TransitPortInfo, error)
+	DeleteTransitPort(id string) error
+	ListTransitPorts() []*TransitPortInfo
+
+	SavePolicy(policy *PolicyInfo) error
+	GetPolicy(id string) (*PolicyInfo, error)
+	DeletePolicy(id string) error
+	ListPolicies() []*PolicyInfo
+
+	SaveLB(lb *LBInfo) error
+	GetLB(id string) (*LBInfo, error)
+	DeleteLB(id string) error
+	ListLBs() []*LBInfo
+
+	// SetRecoveryReport records the result of this instance's most recent
+	// startup reconciliation, so operators can query it to alert on drift.
+	SetRecoveryReport(r RecoveryReport)
+	RecoveryReport() RecoveryReport
+
+	// SetLocalChassisID and LocalChassisID track the most recently observed
+	// local OVN chassis ID for this instance, so a caller can detect it
+	// changing underneath a long-running process (e.g. a reprovisioned
+	// "external_ids:system-id") instead of silently keeping stale
+	// gateway/HA chassis pins. Like SetRecoveryReport, this is re-derived on
+	// every restart rather than persisted durably.
+	SetLocalChassisID(id string)
+	LocalChassisID() string
+
+	// ActiveEndpoints returns the endpoints this instance believes are
+	// still live, either seeded by WithActiveEndpoints at construction or
+	// recorded by the most recent SetRecoveryReport.
+	ActiveEndpoints() map[string]EndpointRuntimeState
+
+	// Reconcile cross-checks this store's endpoints against the logical
+	// ports ovnClient reports OVN itself still has, returning a
+	// *ReconcileError an operator can act on if they've drifted apart.
+	Reconcile(ctx context.Context, ovnClient OVNPortLister) error
+
+	// AddFinalizer records that ownerID (a network or endpoint ID) is using
+	// the OVN resource identified by kind/resource, creating the
+	// finalizer's entry if ownerID is its first owner.
+	AddFinalizer(kind FinalizerKind, resource, ownerID string) error
+	// RemoveFinalizer removes ownerID from resource's owner set and reports
+	// whether the set is now empty, so the caller knows whether it's safe
+	// to delete the underlying OVN resource.
+	RemoveFinalizer(kind FinalizerKind, resource, ownerID string) (empty bool, err error)
+	// ListFinalizers returns every finalizer currently on record, for
+	// ReconcileFinalizers to cross-check against the networks/endpoints
+	// that still exist.
+	ListFinalizers() []*Finalizer
+
+	// Watch streams create/update/delete events for networks and endpoints
+	// until ctx is cancelled, so a clustered driver can react to changes
+	// made by another instance sharing the same backend.
+	Watch(ctx context.Context) <-chan Event
+
+	Close() error
+}
+
+// OVNPortLister is the minimal capability Reconcile needs from an OVN
+// client: reading back the logical ports OVN itself believes exist, so
+// they can be cross-checked against this store's endpoints.
+// *ovn.Client.RehydrateLogicalSwitches satisfies it.
+type OVNPortLister interface {
+	RehydrateLogicalSwitches() ([]ovn.LogicalSwitchState, error)
+}
+
+// ReconcileError reports persisted endpoints whose OVN logical port no
+// longer exists, as found by Reconcile.
+type ReconcileError struct {
+	MissingOVNPorts []string
+}
+
+func (e *ReconcileError) Error() string {
+	return fmt.Sprintf("store: %d endpoint(s) have no matching OVN logical port: %s",
+		len(e.MissingOVNPorts), strings.Join(e.MissingOVNPorts, ", "))
 }
 
-// NewStore creates a new persistent store
-func NewStore(dataDir string) (*Store, error) {
-	if dataDir == "" {
-		dataDir = "/data"
+// Option configures optional behavior on a Store constructed by New.
+type Option func(*kvStore)
+
+// WithActiveEndpoints seeds the store with the endpoints a caller already
+// knows are live - e.g. from a host scan the driver ran before loading the
+// store - mirroring libnetwork's OptionActiveSandboxes. Most callers don't
+// need this: once the store is loaded, recoverState's own scan reaches the
+// same information via SetRecoveryReport.
+func WithActiveEndpoints(active map[string]EndpointRuntimeState) Option {
+	return func(s *kvStore) {
+		s.activeEndpoints = active
 	}
+}
+
+// New constructs a Store from a URL: "bolt:///data/store.db" (the default),
+// "file:///data" (the pre-bolt backend, kept for compatibility),
+// "etcd://host1:2379,host2:2379", or "consul://host:8500". It is
+// NewStoreWithBackend with no legacy data to migrate.
+func New(storeURL string, logger *logrus.Logger, opts ...Option) (Store, error) {
+	return NewStoreWithBackend(BackendConfig{URL: storeURL}, logger, opts...)
+}
 
-	// Create data directory if it doesn't exist
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create data directory: %w", err)
+// BackendConfig selects a Store's backend and, optionally, a legacy
+// file-backend directory to import from on first open.
+type BackendConfig struct {
+	// URL is the backend to use: "bolt:///data/store.db" (the default),
+	// "file:///data", "etcd://host:2379", or "consul://host:8500".
+	URL string
+	// MigrateFromDir, if set, is a pkg/store/file data directory (one JSON
+	// file per key) to import into the selected backend the first time it's
+	// opened empty - so upgrading a single-instance deployment from the
+	// file backend to bolt (or to etcd/consul for a move to HA) doesn't
+	// lose existing networks and endpoints.
+	MigrateFromDir string
+}
+
+const defaultBoltPath = "/data/store.db"
+
+// NewStoreWithBackend constructs a Store from cfg, applying MigrateFromDir's
+// legacy data (if any, and if the backend is still empty) before opts run.
+func NewStoreWithBackend(cfg BackendConfig, logger *logrus.Logger, opts ...Option) (Store, error) {
+	storeURL := cfg.URL
+	if storeURL == "" {
+		storeURL = "bolt://" + defaultBoltPath
 	}
 
-	s := &Store{
-		dataDir:   dataDir,
-		networks:  make(map[string]*NetworkInfo),
-		endpoints: make(map[string]*EndpointInfo),
+	u, err := url.Parse(storeURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid store URL %q: %w", storeURL, err)
 	}
 
-	// Load existing state
-	if err := s.load(); err != nil {
-		return nil, fmt.Errorf("failed to load state: %w", err)
+	var backend kv.KV
+	switch u.Scheme {
+	case "bolt", "":
+		path := u.Path
+		if path == "" {
+			path = defaultBoltPath
+		}
+		backend, err = bolt.New(path)
+		if err == nil {
+			logger.Infof("Using bolt-backed store at %s", path)
+		}
+	case "file":
+		dir := u.Path
+		if dir == "" {
+			dir = "/data"
+		}
+		backend, err = file.New(dir)
+		if err == nil {
+			logger.Infof("Using file-backed store at %s", dir)
+		}
+	case "etcd":
+		endpoints := strings.Split(u.Host, ",")
+		backend, err = etcd.New(endpoints)
+		if err == nil {
+			logger.Infof("Using etcd-backed store at %s", u.Host)
+		}
+	case "consul":
+		backend, err = consul.New(u.Host)
+		if err == nil {
+			logger.Infof("Using consul-backed store at %s", u.Host)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported store scheme %q (want bolt, file, etcd, or consul)", u.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s store: %w", u.Scheme, err)
+	}
+
+	if cfg.MigrateFromDir != "" {
+		if err := migrateLegacyFileStore(backend, cfg.MigrateFromDir, logger); err != nil {
+			return nil, err
+		}
 	}
 
+	s := newKVStore(backend)
+	for _, opt := range opts {
+		opt(s)
+	}
 	return s, nil
 }
 
-// SaveNetwork persists network configuration
-func (s *Store) SaveNetwork(network *NetworkInfo) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// migrateLegacyFileStore imports dir (a pkg/store/file data directory) into
+// backend as a single Txn, but only if backend doesn't already hold data -
+// it must never overwrite a backend a newer plugin version has already
+// written to.
+func migrateLegacyFileStore(backend kv.KV, dir string, logger *logrus.Logger) error {
+	ctx := context.Background()
 
-	s.networks[network.ID] = network
-	return s.persist()
-}
+	existing, err := backend.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to check backend for existing data before migration: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil
+	}
 
-// GetNetwork retrieves network configuration
-func (s *Store) GetNetwork(networkID string) (*NetworkInfo, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	legacy, err := file.New(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open legacy file store at %s for migration: %w", dir, err)
+	}
+	defer legacy.Close()
 
-	network, ok := s.networks[networkID]
-	if !ok {
-		return nil, fmt.Errorf("network %s not found", networkID)
+	puts, err := legacy.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to read legacy file store at %s for migration: %w", dir, err)
 	}
-	return network, nil
+	if len(puts) == 0 {
+		return nil
+	}
+
+	if err := backend.Txn(ctx, puts); err != nil {
+		return fmt.Errorf("failed to migrate %d key(s) from %s: %w", len(puts), dir, err)
+	}
+	logger.Infof("Migrated %d key(s) from legacy file store at %s", len(puts), dir)
+	return nil
 }
 
-// DeleteNetwork removes network configuration
-func (s *Store) DeleteNetwork(networkID string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// kvStore implements Store on top of a kv.KV, storing one key per network
+// (under "networks/") and one per endpoint (under "endpoints/"), so
+// concurrent updates to different networks/endpoints no longer stomp on
+// each other the way the old single-file-per-collection format did.
+type kvStore struct {
+	backend kv.KV
+
+	mu              sync.Mutex
+	recovery        RecoveryReport // local to this instance; not shared across the backend
+	activeEndpoints map[string]EndpointRuntimeState
+	localChassisID  string // local to this instance; not shared across the backend
+}
 
-	delete(s.networks, networkID)
-	return s.persist()
+func newKVStore(backend kv.KV) *kvStore {
+	return &kvStore{backend: backend}
 }
 
-// SaveEndpoint persists endpoint configuration
-func (s *Store) SaveEndpoint(endpoint *EndpointInfo) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+func endpointKey(networkID, endpointID string) string {
+	return endpointPrefix + networkID + ":" + endpointID
+}
+
+func (s *kvStore) SaveNetwork(network *NetworkInfo) error {
+	data, err := json.Marshal(network)
+	if err != nil {
+		return fmt.Errorf("failed to marshal network %s: %w", network.ID, err)
+	}
+	if err := s.backend.Put(context.Background(), networkPrefix+network.ID, data); err != nil {
+		return fmt.Errorf("failed to save network %s: %w", network.ID, err)
+	}
+	return nil
+}
+
+func (s *kvStore) GetNetwork(networkID string) (*NetworkInfo, error) {
+	data, err := s.backend.Get(context.Background(), networkPrefix+networkID)
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil, fmt.Errorf("network %s not found", networkID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network %s: %w", networkID, err)
+	}
+	var network NetworkInfo
+	if err := json.Unmarshal(data, &network); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal network %s: %w", networkID, err)
+	}
+	return &network, nil
+}
 
-	key := fmt.Sprintf("%s:%s", endpoint.NetworkID, endpoint.EndpointID)
-	s.endpoints[key] = endpoint
-	return s.persist()
+func (s *kvStore) DeleteNetwork(networkID string) error {
+	if err := s.backend.Delete(context.Background(), networkPrefix+networkID); err != nil {
+		return fmt.Errorf("failed to delete network %s: %w", networkID, err)
+	}
+	return nil
 }
 
-// GetEndpoint retrieves endpoint configuration
-func (s *Store) GetEndpoint(networkID, endpointID string) (*EndpointInfo, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+func (s *kvStore) ListNetworks() []*NetworkInfo {
+	items, err := s.backend.List(context.Background(), networkPrefix)
+	if err != nil {
+		return nil
+	}
+	networks := make([]*NetworkInfo, 0, len(items))
+	for _, data := range items {
+		var network NetworkInfo
+		if err := json.Unmarshal(data, &network); err != nil {
+			continue
+		}
+		networks = append(networks, &network)
+	}
+	return networks
+}
 
-	key := fmt.Sprintf("%s:%s", networkID, endpointID)
-	endpoint, ok := s.endpoints[key]
-	if !ok {
+func (s *kvStore) SaveEndpoint(endpoint *EndpointInfo) error {
+	data, err := json.Marshal(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal endpoint %s: %w", endpoint.EndpointID, err)
+	}
+	key := endpointKey(endpoint.NetworkID, endpoint.EndpointID)
+	if err := s.backend.Put(context.Background(), key, data); err != nil {
+		return fmt.Errorf("failed to save endpoint %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *kvStore) GetEndpoint(networkID, endpointID string) (*EndpointInfo, error) {
+	key := endpointKey(networkID, endpointID)
+	data, err := s.backend.Get(context.Background(), key)
+	if errors.Is(err, kv.ErrNotFound) {
 		return nil, fmt.Errorf("endpoint %s not found", key)
 	}
-	return endpoint, nil
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint %s: %w", key, err)
+	}
+	var endpoint EndpointInfo
+	if err := json.Unmarshal(data, &endpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal endpoint %s: %w", key, err)
+	}
+	return &endpoint, nil
 }
 
-// DeleteEndpoint removes endpoint configuration
-func (s *Store) DeleteEndpoint(networkID, endpointID string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+func (s *kvStore) DeleteEndpoint(networkID, endpointID string) error {
+	key := endpointKey(networkID, endpointID)
+	if err := s.backend.Delete(context.Background(), key); err != nil {
+		return fmt.Errorf("failed to delete endpoint %s: %w", key, err)
+	}
+	return nil
+}
 
-	key := fmt.Sprintf("%s:%s", networkID, endpointID)
-	delete(s.endpoints, key)
-	return s.persist()
+func (s *kvStore) ListEndpoints() []*EndpointInfo {
+	items, err := s.backend.List(context.Background(), endpointPrefix)
+	if err != nil {
+		return nil
+	}
+	endpoints := make([]*EndpointInfo, 0, len(items))
+	for _, data := range items {
+		var endpoint EndpointInfo
+		if err := json.Unmarshal(data, &endpoint); err != nil {
+			continue
+		}
+		endpoints = append(endpoints, &endpoint)
+	}
+	return endpoints
 }
 
-// ListNetworks returns all networks
-func (s *Store) ListNetworks() []*NetworkInfo {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+func (s *kvStore) SaveEIP(eip *EIPInfo) error {
+	data, err := json.Marshal(eip)
+	if err != nil {
+		return fmt.Errorf("failed to marshal eip %s: %w", eip.ID, err)
+	}
+	if err := s.backend.Put(context.Background(), eipPrefix+eip.ID, data); err != nil {
+		return fmt.Errorf("failed to save eip %s: %w", eip.ID, err)
+	}
+	return nil
+}
 
-	networks := make([]*NetworkInfo, 0, len(s.networks))
-	for _, network := range s.networks {
-		networks = append(networks, network)
+func (s *kvStore) GetEIP(eipID string) (*EIPInfo, error) {
+	data, err := s.backend.Get(context.Background(), eipPrefix+eipID)
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil, fmt.Errorf("eip %s not found", eipID)
 	}
-	return networks
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eip %s: %w", eipID, err)
+	}
+	var eip EIPInfo
+	if err := json.Unmarshal(data, &eip); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal eip %s: %w", eipID, err)
+	}
+	return &eip, nil
 }
 
-// ListEndpoints returns all endpoints
-func (s *Store) ListEndpoints() []*EndpointInfo {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+func (s *kvStore) DeleteEIP(eipID string) error {
+	if err := s.backend.Delete(context.Background(), eipPrefix+eipID); err != nil {
+		return fmt.Errorf("failed to delete eip %s: %w", eipID, err)
+	}
+	return nil
+}
 
-	endpoints := make([]*EndpointInfo, 0, len(s.endpoints))
-	for _, endpoint := range s.endpoints {
-		endpoints = append(endpoints, endpoint)
+func (s *kvStore) ListEIPs() []*EIPInfo {
+	items, err := s.backend.List(context.Background(), eipPrefix)
+	if err != nil {
+		return nil
 	}
-	return endpoints
+	eips := make([]*EIPInfo, 0, len(items))
+	for _, data := range items {
+		var eip EIPInfo
+		if err := json.Unmarshal(data, &eip); err != nil {
+			continue
+		}
+		eips = append(eips, &eip)
+	}
+	return eips
 }
 
-// persist saves state to disk
-func (s *Store) persist() error {
-	// Save networks
-	networksFile := filepath.Join(s.dataDir, "networks.json")
-	data, err := json.MarshalIndent(s.networks, "", "  ")
+func (s *kvStore) SaveTransitPort(port *TransitPortInfo) error {
+	data, err := json.Marshal(port)
 	if err != nil {
-		return fmt.Errorf("failed to marshal networks: %w", err)
+		return fmt.Errorf("failed to marshal transit port %s: %w", port.ID, err)
 	}
-	if err := ioutil.WriteFile(networksFile, data, 0644); err != nil {
-		return fmt.Errorf("failed to write networks file: %w", err)
+	if err := s.backend.Put(context.Background(), transitPortPrefix+port.ID, data); err != nil {
+		return fmt.Errorf("failed to save transit port %s: %w", port.ID, err)
 	}
+	return nil
+}
 
-	// Save endpoints
-	endpointsFile := filepath.Join(s.dataDir, "endpoints.json")
-	data, err = json.MarshalIndent(s.endpoints, "", "  ")
+func (s *kvStore) GetTransitPort(id string) (*TransitPortInfo, error) {
+	data, err := s.backend.Get(context.Background(), transitPortPrefix+id)
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil, fmt.Errorf("transit port %s not found", id)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to marshal endpoints: %w", err)
+		return nil, fmt.Errorf("failed to get transit port %s: %w", id, err)
 	}
-	if err := ioutil.WriteFile(endpointsFile, data, 0644); err != nil {
-		return fmt.Errorf("failed to write endpoints file: %w", err)
+	var port TransitPortInfo
+	if err := json.Unmarshal(data, &port); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transit port %s: %w", id, err)
 	}
+	return &port, nil
+}
 
+func (s *kvStore) DeleteTransitPort(id string) error {
+	if err := s.backend.Delete(context.Background(), transitPortPrefix+id); err != nil {
+		return fmt.Errorf("failed to delete transit port %s: %w", id, err)
+	}
 	return nil
 }
 
-// load reads state from disk
-func (s *Store) load() error {
-	// Load networks
-	networksFile := filepath.Join(s.dataDir, "networks.json")
-	if data, err := ioutil.ReadFile(networksFile); err == nil {
-		if err := json.Unmarshal(data, &s.networks); err != nil {
-			return fmt.Errorf("failed to unmarshal networks: %w", err)
+func (s *kvStore) ListTransitPorts() []*TransitPortInfo {
+	items, err := s.backend.List(context.Background(), transitPortPrefix)
+	if err != nil {
+		return nil
+	}
+	ports := make([]*TransitPortInfo, 0, len(items))
+	for _, data := range items {
+		var port TransitPortInfo
+		if err := json.Unmarshal(data, &port); err != nil {
+			continue
 		}
+		ports = append(ports, &port)
+	}
+	return ports
+}
+
+func (s *kvStore) SavePolicy(policy *PolicyInfo) error {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy %s: %w", policy.ID, err)
+	}
+	if err := s.backend.Put(context.Background(), policyPrefix+policy.ID, data); err != nil {
+		return fmt.Errorf("failed to save policy %s: %w", policy.ID, err)
+	}
+	return nil
+}
+
+func (s *kvStore) GetPolicy(id string) (*PolicyInfo, error) {
+	data, err := s.backend.Get(context.Background(), policyPrefix+id)
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil, fmt.Errorf("policy %s not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy %s: %w", id, err)
+	}
+	var policy PolicyInfo
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy %s: %w", id, err)
 	}
+	return &policy, nil
+}
 
-	// Load endpoints
-	endpointsFile := filepath.Join(s.dataDir, "endpoints.json")
-	if data, err := ioutil.ReadFile(endpointsFile); err == nil {
-		if err := json.Unmarshal(data, &s.endpoints); err != nil {
-			return fmt.Errorf("failed to unmarshal endpoints: %w", err)
+func (s *kvStore) DeletePolicy(id string) error {
+	if err := s.backend.Delete(context.Background(), policyPrefix+id); err != nil {
+		return fmt.Errorf("failed to delete policy %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *kvStore) ListPolicies() []*PolicyInfo {
+	items, err := s.backend.List(context.Background(), policyPrefix)
+	if err != nil {
+		return nil
+	}
+	policies := make([]*PolicyInfo, 0, len(items))
+	for _, data := range items {
+		var policy PolicyInfo
+		if err := json.Unmarshal(data, &policy); err != nil {
+			continue
 		}
+		policies = append(policies, &policy)
 	}
+	return policies
+}
 
+func (s *kvStore) SaveLB(lb *LBInfo) error {
+	data, err := json.Marshal(lb)
+	if err != nil {
+		return fmt.Errorf("failed to marshal load balancer %s: %w", lb.ID, err)
+	}
+	if err := s.backend.Put(context.Background(), lbPrefix+lb.ID, data); err != nil {
+		return fmt.Errorf("failed to save load balancer %s: %w", lb.ID, err)
+	}
 	return nil
 }
 
-// Recover attempts to recover network state on plugin restart
-func (s *Store) Recover() error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+func (s *kvStore) GetLB(id string) (*LBInfo, error) {
+	data, err := s.backend.Get(context.Background(), lbPrefix+id)
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil, fmt.Errorf("load balancer %s not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get load balancer %s: %w", id, err)
+	}
+	var lb LBInfo
+	if err := json.Unmarshal(data, &lb); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal load balancer %s: %w", id, err)
+	}
+	return &lb, nil
+}
 
-	// Log what we're recovering
-	fmt.Printf("Recovering plugin state: %d networks, %d endpoints\n",
-		len(s.networks), len(s.endpoints))
+func (s *kvStore) DeleteLB(id string) error {
+	if err := s.backend.Delete(context.Background(), lbPrefix+id); err != nil {
+		return fmt.Errorf("failed to delete load balancer %s: %w", id, err)
+	}
+	return nil
+}
 
-	// Here you would:
-	// 1. Verify OVS bridges still exist
-	// 2. Check OVN logical switches/routers still exist
-	// 3. Verify veth pairs are still connected
-	// 4. Re-establish any missing connections
-	// 5. Clean up orphaned resources
+func (s *kvStore) ListLBs() []*LBInfo {
+	items, err := s.backend.List(context.Background(), lbPrefix)
+	if err != nil {
+		return nil
+	}
+	lbs := make([]*LBInfo, 0, len(items))
+	for _, data := range items {
+		var lb LBInfo
+		if err := json.Unmarshal(data, &lb); err != nil {
+			continue
+		}
+		lbs = append(lbs, &lb)
+	}
+	return lbs
+}
+
+func finalizerKey(kind FinalizerKind, resource string) string {
+	return finalizerPrefix + string(kind) + ":" + resource
+}
 
+func (s *kvStore) AddFinalizer(kind FinalizerKind, resource, ownerID string) error {
+	key := finalizerKey(kind, resource)
+	err := s.backend.CAS(context.Background(), key, func(current []byte, exists bool) ([]byte, error) {
+		f := Finalizer{Kind: kind, Resource: resource, Owners: map[string]bool{}}
+		if exists {
+			if err := json.Unmarshal(current, &f); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal finalizer %s: %w", key, err)
+			}
+			if f.Owners == nil {
+				f.Owners = map[string]bool{}
+			}
+		}
+		f.Owners[ownerID] = true
+		return json.Marshal(&f)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add owner %s to finalizer %s: %w", ownerID, key, err)
+	}
 	return nil
 }
+
+func (s *kvStore) RemoveFinalizer(kind FinalizerKind, resource, ownerID string) (bool, error) {
+	key := finalizerKey(kind, resource)
+	empty := true
+	err := s.backend.CAS(context.Background(), key, func(current []byte, exists bool) ([]byte, error) {
+		if !exists {
+			return nil, nil
+		}
+		var f Finalizer
+		if err := json.Unmarshal(current, &f); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal finalizer %s: %w", key, err)
+		}
+		delete(f.Owners, ownerID)
+		if len(f.Owners) == 0 {
+			return nil, nil // a nil result deletes the key
+		}
+		empty = false
+		return json.Marshal(&f)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to remove owner %s from finalizer %s: %w", ownerID, key, err)
+	}
+	return empty, nil
+}
+
+func (s *kvStore) ListFinalizers() []*Finalizer {
+	items, err := s.backend.List(context.Background(), finalizerPrefix)
+	if err != nil {
+		return nil
+	}
+	finalizers := make([]*Finalizer, 0, len(items))
+	for _, data := range items {
+		var f Finalizer
+		if err := json.Unmarshal(data, &f); err != nil {
+			continue
+		}
+		finalizers = append(finalizers, &f)
+	}
+	return finalizers
+}
+
+func (s *kvStore) SetRecoveryReport(r RecoveryReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recovery = r
+}
+
+func (s *kvStore) RecoveryReport() RecoveryReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recovery
+}
+
+func (s *kvStore) SetLocalChassisID(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.localChassisID = id
+}
+
+func (s *kvStore) LocalChassisID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.localChassisID
+}
+
+func (s *kvStore) ActiveEndpoints() map[string]EndpointRuntimeState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.activeEndpoints != nil {
+		return s.activeEndpoints
+	}
+	return s.recovery.ActiveEndpoints
+}
+
+func (s *kvStore) Reconcile(ctx context.Context, ovnClient OVNPortLister) error {
+	switches, err := ovnClient.RehydrateLogicalSwitches()
+	if err != nil {
+		return fmt.Errorf("store: failed to read OVN logical switches for reconciliation: %w", err)
+	}
+
+	knownPorts := make(map[string]bool)
+	for _, sw := range switches {
+		for _, port := range sw.PortNames {
+			knownPorts[port] = true
+		}
+	}
+
+	var missing []string
+	for _, ep := range s.ListEndpoints() {
+		if ep.OVNPort == "" || knownPorts[ep.OVNPort] {
+			continue
+		}
+		missing = append(missing, ep.OVNPort)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &ReconcileError{MissingOVNPorts: missing}
+}
+
+func (s *kvStore) Watch(ctx context.Context) <-chan Event {
+	out := make(chan Event)
+	networks := s.backend.Watch(ctx, networkPrefix)
+	endpoints := s.backend.Watch(ctx, endpointPrefix)
+
+	go func() {
+		defer close(out)
+		for networks != nil || endpoints != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-networks:
+				if !ok {
+					networks = nil
+					continue
+				}
+				out <- Event{Type: ev.Type, NetworkID: strings.TrimPrefix(ev.Key, networkPrefix)}
+			case ev, ok := <-endpoints:
+				if !ok {
+					endpoints = nil
+					continue
+				}
+				rest := strings.TrimPrefix(ev.Key, endpointPrefix)
+				parts := strings.SplitN(rest, ":", 2)
+				if len(parts) != 2 {
+					continue
+				}
+				out <- Event{Type: ev.Type, NetworkID: parts[0], EndpointID: parts[1]}
+			}
+		}
+	}()
+
+	return out
+}
+
+func (s *kvStore) Close() error {
+	return s.backend.Close()
+}
@@ -1,51 +1,89 @@
 package store
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/ovs-container-lab/ovs-container-network/pkg/ovn"
+	"github.com/sirupsen/logrus"
 )
 
-func TestNewStore(t *testing.T) {
-	// Create temp directory
+func newTestStore(t *testing.T) (Store, string) {
+	t.Helper()
 	tmpDir, err := ioutil.TempDir("", "store_test")
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer os.RemoveAll(tmpDir)
+	t.Cleanup(func() { os.RemoveAll(tmpDir) })
 
-	// Test creating new store
-	store, err := NewStore(tmpDir)
+	s, err := New("file://"+tmpDir, logrus.New())
 	if err != nil {
 		t.Fatalf("Failed to create store: %v", err)
 	}
+	return s, tmpDir
+}
 
-	if store.dataDir != tmpDir {
-		t.Errorf("Expected dataDir to be %s, got %s", tmpDir, store.dataDir)
+func TestNew(t *testing.T) {
+	s, _ := newTestStore(t)
+	if s == nil {
+		t.Fatal("Expected non-nil store")
 	}
+}
 
-	// Verify directories exist
-	if _, err := os.Stat(tmpDir); os.IsNotExist(err) {
-		t.Error("Data directory was not created")
+func TestNewUnsupportedScheme(t *testing.T) {
+	if _, err := New("redis://localhost:6379", logrus.New()); err == nil {
+		t.Error("Expected error for unsupported store scheme")
 	}
 }
 
-func TestNetworkPersistence(t *testing.T) {
-	tmpDir, err := ioutil.TempDir("", "store_test")
+func TestNewStoreWithBackendMigratesLegacyFileStore(t *testing.T) {
+	legacyDir, err := ioutil.TempDir("", "store_migrate_legacy")
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer os.RemoveAll(tmpDir)
+	t.Cleanup(func() { os.RemoveAll(legacyDir) })
+
+	legacy, err := New("file://"+legacyDir, logrus.New())
+	if err != nil {
+		t.Fatalf("Failed to create legacy store: %v", err)
+	}
+	if err := legacy.SaveNetwork(&NetworkInfo{ID: "net1", Name: "legacy-net"}); err != nil {
+		t.Fatalf("Failed to seed legacy store: %v", err)
+	}
+	legacy.Close()
 
-	store, err := NewStore(tmpDir)
+	boltDir, err := ioutil.TempDir("", "store_migrate_bolt")
 	if err != nil {
 		t.Fatal(err)
 	}
+	t.Cleanup(func() { os.RemoveAll(boltDir) })
+
+	s, err := NewStoreWithBackend(BackendConfig{
+		URL:            "bolt://" + boltDir + "/store.db",
+		MigrateFromDir: legacyDir,
+	}, logrus.New())
+	if err != nil {
+		t.Fatalf("NewStoreWithBackend failed: %v", err)
+	}
+	defer s.Close()
+
+	net, err := s.GetNetwork("net1")
+	if err != nil {
+		t.Fatalf("Expected migrated network to be readable: %v", err)
+	}
+	if net.Name != "legacy-net" {
+		t.Errorf("Expected migrated network name 'legacy-net', got %q", net.Name)
+	}
+}
+
+func TestNetworkPersistence(t *testing.T) {
+	s, _ := newTestStore(t)
 
-	// Create test network
 	network := &NetworkInfo{
 		ID:        "test-net-123",
 		Name:      "test-network",
@@ -59,24 +97,14 @@ func TestNetworkPersistence(t *testing.T) {
 		},
 	}
 
-	// Save network
-	if err := store.SaveNetwork(network); err != nil {
+	if err := s.SaveNetwork(network); err != nil {
 		t.Fatalf("Failed to save network: %v", err)
 	}
 
-	// Verify file exists
-	networkFile := filepath.Join(tmpDir, "networks.json")
-	if _, err := os.Stat(networkFile); os.IsNotExist(err) {
-		t.Error("Networks file was not created")
-	}
-
-	// Retrieve network
-	retrieved, err := store.GetNetwork("test-net-123")
+	retrieved, err := s.GetNetwork("test-net-123")
 	if err != nil {
 		t.Fatalf("Failed to retrieve network: %v", err)
 	}
-
-	// Verify fields match
 	if retrieved.ID != network.ID {
 		t.Errorf("ID mismatch: expected %s, got %s", network.ID, retrieved.ID)
 	}
@@ -87,37 +115,22 @@ func TestNetworkPersistence(t *testing.T) {
 		t.Error("Options not preserved correctly")
 	}
 
-	// Test listing networks
-	networks := store.ListNetworks()
+	networks := s.ListNetworks()
 	if len(networks) != 1 {
 		t.Errorf("Expected 1 network, got %d", len(networks))
 	}
 
-	// Delete network
-	if err := store.DeleteNetwork("test-net-123"); err != nil {
+	if err := s.DeleteNetwork("test-net-123"); err != nil {
 		t.Fatalf("Failed to delete network: %v", err)
 	}
-
-	// Verify it's gone
-	_, err = store.GetNetwork("test-net-123")
-	if err == nil {
+	if _, err := s.GetNetwork("test-net-123"); err == nil {
 		t.Error("Expected error when retrieving deleted network")
 	}
 }
 
 func TestEndpointPersistence(t *testing.T) {
-	tmpDir, err := ioutil.TempDir("", "store_test")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(tmpDir)
-
-	store, err := NewStore(tmpDir)
-	if err != nil {
-		t.Fatal(err)
-	}
+	s, _ := newTestStore(t)
 
-	// Create test endpoint
 	endpoint := &EndpointInfo{
 		ID:          "net1:ep1",
 		NetworkID:   "net1",
@@ -130,24 +143,14 @@ func TestEndpointPersistence(t *testing.T) {
 		OVNPort:     "lsp-ep1",
 	}
 
-	// Save endpoint
-	if err := store.SaveEndpoint(endpoint); err != nil {
+	if err := s.SaveEndpoint(endpoint); err != nil {
 		t.Fatalf("Failed to save endpoint: %v", err)
 	}
 
-	// Verify file exists
-	endpointFile := filepath.Join(tmpDir, "endpoints.json")
-	if _, err := os.Stat(endpointFile); os.IsNotExist(err) {
-		t.Error("Endpoints file was not created")
-	}
-
-	// Retrieve endpoint
-	retrieved, err := store.GetEndpoint("net1", "ep1")
+	retrieved, err := s.GetEndpoint("net1", "ep1")
 	if err != nil {
 		t.Fatalf("Failed to retrieve endpoint: %v", err)
 	}
-
-	// Verify fields match
 	if retrieved.IPAddress != endpoint.IPAddress {
 		t.Errorf("IP mismatch: expected %s, got %s", endpoint.IPAddress, retrieved.IPAddress)
 	}
@@ -155,64 +158,35 @@ func TestEndpointPersistence(t *testing.T) {
 		t.Errorf("Veth mismatch: expected %s, got %s", endpoint.VethName, retrieved.VethName)
 	}
 
-	// Test listing endpoints
-	endpoints := store.ListEndpoints()
+	endpoints := s.ListEndpoints()
 	if len(endpoints) != 1 {
 		t.Errorf("Expected 1 endpoint, got %d", len(endpoints))
 	}
 
-	// Delete endpoint
-	if err := store.DeleteEndpoint("net1", "ep1"); err != nil {
+	if err := s.DeleteEndpoint("net1", "ep1"); err != nil {
 		t.Fatalf("Failed to delete endpoint: %v", err)
 	}
-
-	// Verify it's gone
-	_, err = store.GetEndpoint("net1", "ep1")
-	if err == nil {
+	if _, err := s.GetEndpoint("net1", "ep1"); err == nil {
 		t.Error("Expected error when retrieving deleted endpoint")
 	}
 }
 
 func TestStoreRecovery(t *testing.T) {
-	tmpDir, err := ioutil.TempDir("", "store_test")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(tmpDir)
+	s1, tmpDir := newTestStore(t)
 
-	// Create initial store and save data
-	store1, err := NewStore(tmpDir)
-	if err != nil {
-		t.Fatal(err)
-	}
+	network := &NetworkInfo{ID: "persist-net", Name: "persistent", Bridge: "br-persist", VLAN: 200, TenantID: "tenant-2"}
+	endpoint := &EndpointInfo{ID: "persist-net:persist-ep", NetworkID: "persist-net", EndpointID: "persist-ep", IPAddress: "192.168.1.10/24", VethName: "veth999"}
 
-	network := &NetworkInfo{
-		ID:       "persist-net",
-		Name:     "persistent",
-		Bridge:   "br-persist",
-		VLAN:     200,
-		TenantID: "tenant-2",
-	}
+	s1.SaveNetwork(network)
+	s1.SaveEndpoint(endpoint)
 
-	endpoint := &EndpointInfo{
-		ID:         "persist-net:persist-ep",
-		NetworkID:  "persist-net",
-		EndpointID: "persist-ep",
-		IPAddress:  "192.168.1.10/24",
-		VethName:   "veth999",
-	}
-
-	store1.SaveNetwork(network)
-	store1.SaveEndpoint(endpoint)
-
-	// Simulate plugin restart by creating new store instance
-	store2, err := NewStore(tmpDir)
+	// Simulate plugin restart by creating a new store instance over the same directory.
+	s2, err := New("file://"+tmpDir, logrus.New())
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	// Verify data was recovered
-	recoveredNet, err := store2.GetNetwork("persist-net")
+	recoveredNet, err := s2.GetNetwork("persist-net")
 	if err != nil {
 		t.Fatalf("Failed to recover network: %v", err)
 	}
@@ -220,7 +194,7 @@ func TestStoreRecovery(t *testing.T) {
 		t.Errorf("Network not recovered correctly, VLAN expected 200, got %d", recoveredNet.VLAN)
 	}
 
-	recoveredEp, err := store2.GetEndpoint("persist-net", "persist-ep")
+	recoveredEp, err := s2.GetEndpoint("persist-net", "persist-ep")
 	if err != nil {
 		t.Fatalf("Failed to recover endpoint: %v", err)
 	}
@@ -228,103 +202,116 @@ func TestStoreRecovery(t *testing.T) {
 		t.Errorf("Endpoint not recovered correctly, IP expected 192.168.1.10/24, got %s", recoveredEp.IPAddress)
 	}
 
-	// Verify recovery populated the lists
-	if len(store2.ListNetworks()) != 1 {
+	if len(s2.ListNetworks()) != 1 {
 		t.Error("Network list not populated on recovery")
 	}
-	if len(store2.ListEndpoints()) != 1 {
+	if len(s2.ListEndpoints()) != 1 {
 		t.Error("Endpoint list not populated on recovery")
 	}
-}
 
-func TestCorruptedStateHandling(t *testing.T) {
-	tmpDir, err := ioutil.TempDir("", "store_test")
+	// WithActiveEndpoints seeds ActiveEndpoints, mirroring libnetwork's
+	// OptionActiveSandboxes, for callers that already know which endpoints
+	// are live before they load the rest of the store's state.
+	active := map[string]EndpointRuntimeState{
+		"persist-ep": {NetworkID: "persist-net", EndpointID: "persist-ep", VethName: "veth999"},
+	}
+	s3, err := New("file://"+tmpDir, logrus.New(), WithActiveEndpoints(active))
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer os.RemoveAll(tmpDir)
+	if got := s3.ActiveEndpoints(); len(got) != 1 || got["persist-ep"].VethName != "veth999" {
+		t.Errorf("ActiveEndpoints not seeded correctly, got %+v", got)
+	}
 
-	// Write corrupted JSON to networks file
-	networkFile := filepath.Join(tmpDir, "networks.json")
-	corruptedData := []byte(`{"invalid json": "}`)
-	if err := ioutil.WriteFile(networkFile, corruptedData, 0644); err != nil {
+	// Absent a seeded value, ActiveEndpoints falls back to whatever the
+	// most recent SetRecoveryReport recorded.
+	s2.SetRecoveryReport(RecoveryReport{Healthy: 1, ActiveEndpoints: active, MissingVeths: []string{"gone-ep"}})
+	if got := s2.ActiveEndpoints(); len(got) != 1 || got["persist-ep"].NetworkID != "persist-net" {
+		t.Errorf("ActiveEndpoints should fall back to the recovery report, got %+v", got)
+	}
+}
+
+// fakePortLister is a minimal OVNPortLister test double - the real
+// implementation is *ovn.Client.RehydrateLogicalSwitches.
+type fakePortLister struct {
+	switches []ovn.LogicalSwitchState
+	err      error
+}
+
+func (f *fakePortLister) RehydrateLogicalSwitches() ([]ovn.LogicalSwitchState, error) {
+	return f.switches, f.err
+}
+
+func TestReconcile(t *testing.T) {
+	s, _ := newTestStore(t)
+
+	if err := s.SaveEndpoint(&EndpointInfo{ID: "net:ep-ok", NetworkID: "net", EndpointID: "ep-ok", OVNPort: "lsp-ok"}); err != nil {
 		t.Fatal(err)
 	}
+	if err := s.SaveEndpoint(&EndpointInfo{ID: "net:ep-gone", NetworkID: "net", EndpointID: "ep-gone", OVNPort: "lsp-gone"}); err != nil {
+		t.Fatal(err)
+	}
+
+	lister := &fakePortLister{switches: []ovn.LogicalSwitchState{
+		{Name: "ls1", PortNames: []string{"lsp-ok"}},
+	}}
 
-	// Store should handle corrupted file gracefully
-	store, err := NewStore(tmpDir)
+	err := s.Reconcile(context.Background(), lister)
 	if err == nil {
-		t.Log("Store handled corrupted file gracefully")
-	} else {
-		// Depending on implementation, might want to continue with empty state
-		t.Logf("Store returned error for corrupted file: %v", err)
+		t.Fatal("Expected Reconcile to report the missing OVN port")
 	}
-
-	// Should still be able to save new data
-	if store != nil {
-		network := &NetworkInfo{
-			ID:   "new-net",
-			Name: "new",
-		}
-		if err := store.SaveNetwork(network); err != nil {
-			t.Errorf("Failed to save after corruption: %v", err)
-		}
+	reconcileErr, ok := err.(*ReconcileError)
+	if !ok {
+		t.Fatalf("Expected *ReconcileError, got %T", err)
+	}
+	if len(reconcileErr.MissingOVNPorts) != 1 || reconcileErr.MissingOVNPorts[0] != "lsp-gone" {
+		t.Errorf("Expected [lsp-gone], got %v", reconcileErr.MissingOVNPorts)
 	}
 }
 
-func TestConcurrentAccess(t *testing.T) {
-	tmpDir, err := ioutil.TempDir("", "store_test")
-	if err != nil {
+func TestReconcileAgrees(t *testing.T) {
+	s, _ := newTestStore(t)
+
+	if err := s.SaveEndpoint(&EndpointInfo{ID: "net:ep-ok", NetworkID: "net", EndpointID: "ep-ok", OVNPort: "lsp-ok"}); err != nil {
 		t.Fatal(err)
 	}
-	defer os.RemoveAll(tmpDir)
 
-	store, err := NewStore(tmpDir)
-	if err != nil {
-		t.Fatal(err)
+	lister := &fakePortLister{switches: []ovn.LogicalSwitchState{
+		{Name: "ls1", PortNames: []string{"lsp-ok"}},
+	}}
+
+	if err := s.Reconcile(context.Background(), lister); err != nil {
+		t.Errorf("Expected no drift, got %v", err)
 	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	s, _ := newTestStore(t)
 
-	// Test concurrent writes
 	done := make(chan bool, 10)
 	for i := 0; i < 10; i++ {
 		go func(id int) {
-			network := &NetworkInfo{
-				ID:   fmt.Sprintf("net-%d", id),
-				Name: fmt.Sprintf("network-%d", id),
-				VLAN: id,
-			}
-			if err := store.SaveNetwork(network); err != nil {
+			network := &NetworkInfo{ID: fmt.Sprintf("net-%d", id), Name: fmt.Sprintf("network-%d", id), VLAN: id}
+			if err := s.SaveNetwork(network); err != nil {
 				t.Errorf("Concurrent save failed: %v", err)
 			}
 			done <- true
 		}(i)
 	}
 
-	// Wait for all goroutines
 	for i := 0; i < 10; i++ {
 		<-done
 	}
 
-	// Verify all networks were saved
-	networks := store.ListNetworks()
+	networks := s.ListNetworks()
 	if len(networks) != 10 {
 		t.Errorf("Expected 10 networks after concurrent saves, got %d", len(networks))
 	}
 }
 
 func TestIPAMDataPersistence(t *testing.T) {
-	tmpDir, err := ioutil.TempDir("", "store_test")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer os.RemoveAll(tmpDir)
+	s, _ := newTestStore(t)
 
-	store, err := NewStore(tmpDir)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	// Create network with IPAM data
 	ipamData := map[string]interface{}{
 		"Pool":    "10.0.0.0/24",
 		"Gateway": "10.0.0.1",
@@ -332,18 +319,12 @@ func TestIPAMDataPersistence(t *testing.T) {
 	}
 	ipamBytes, _ := json.Marshal(ipamData)
 
-	network := &NetworkInfo{
-		ID:       "ipam-net",
-		Name:     "ipam-network",
-		IPAMData: ipamBytes,
-	}
-
-	if err := store.SaveNetwork(network); err != nil {
+	network := &NetworkInfo{ID: "ipam-net", Name: "ipam-network", IPAMData: ipamBytes}
+	if err := s.SaveNetwork(network); err != nil {
 		t.Fatal(err)
 	}
 
-	// Retrieve and verify IPAM data
-	retrieved, err := store.GetNetwork("ipam-net")
+	retrieved, err := s.GetNetwork("ipam-net")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -352,8 +333,80 @@ func TestIPAMDataPersistence(t *testing.T) {
 	if err := json.Unmarshal(retrieved.IPAMData, &recoveredIPAM); err != nil {
 		t.Fatalf("Failed to unmarshal IPAM data: %v", err)
 	}
-
 	if recoveredIPAM["Pool"] != "10.0.0.0/24" {
 		t.Error("IPAM data not preserved correctly")
 	}
 }
+
+func TestRecoveryReport(t *testing.T) {
+	s, _ := newTestStore(t)
+
+	empty := s.RecoveryReport()
+	if empty.Healthy != 0 || empty.Repaired != 0 || empty.Orphaned != 0 {
+		t.Errorf("Expected zero-value recovery report before reconciliation, got %+v", empty)
+	}
+
+	s.SetRecoveryReport(RecoveryReport{Healthy: 3, Repaired: 1, Orphaned: 2})
+
+	got := s.RecoveryReport()
+	if got.Healthy != 3 || got.Repaired != 1 || got.Orphaned != 2 {
+		t.Errorf("RecoveryReport mismatch: expected {3 1 2}, got %+v", got)
+	}
+}
+
+func TestFinalizers(t *testing.T) {
+	s, _ := newTestStore(t)
+
+	if err := s.AddFinalizer(FinalizerSwitch, "ls-net1", "net1"); err != nil {
+		t.Fatalf("Failed to add finalizer owner net1: %v", err)
+	}
+	if err := s.AddFinalizer(FinalizerSwitch, "ls-net1", "ep1"); err != nil {
+		t.Fatalf("Failed to add finalizer owner ep1: %v", err)
+	}
+
+	finalizers := s.ListFinalizers()
+	if len(finalizers) != 1 || len(finalizers[0].Owners) != 2 {
+		t.Fatalf("Expected 1 finalizer with 2 owners, got %+v", finalizers)
+	}
+
+	empty, err := s.RemoveFinalizer(FinalizerSwitch, "ls-net1", "ep1")
+	if err != nil {
+		t.Fatalf("Failed to remove finalizer owner ep1: %v", err)
+	}
+	if empty {
+		t.Error("Expected finalizer to still have an owner after removing ep1")
+	}
+
+	empty, err = s.RemoveFinalizer(FinalizerSwitch, "ls-net1", "net1")
+	if err != nil {
+		t.Fatalf("Failed to remove finalizer owner net1: %v", err)
+	}
+	if !empty {
+		t.Error("Expected finalizer to be empty after removing its last owner")
+	}
+
+	if finalizers := s.ListFinalizers(); len(finalizers) != 0 {
+		t.Errorf("Expected no finalizers left, got %+v", finalizers)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	s, _ := newTestStore(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := s.Watch(ctx)
+	if err := s.SaveNetwork(&NetworkInfo{ID: "watched-net", Name: "watched"}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Type != EventPut || ev.NetworkID != "watched-net" || ev.EndpointID != "" {
+			t.Errorf("Unexpected event: %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for watch event")
+	}
+}
@@ -6,29 +6,66 @@ import (
 
 // Network represents a network configuration
 type Network struct {
-	ID          string            // Docker network ID
-	Bridge      string            // OVS bridge name
-	TenantID    string            // Tenant identifier
-	VLAN        string            // VLAN tag
-	MTU         string            // Maximum transmission unit
-	OVNSwitch   string            // OVN logical switch name
-	OVNRouter   string            // OVN logical router name
-	MirrorPorts string            // Comma-separated list of ports to mirror
-	MirrorDest  string            // Destination port for mirrored traffic
-	IPv4Data    *network.IPAMData // IPv4 configuration
-	IPv6Data    *network.IPAMData // IPv6 configuration
-	Options     map[string]string // Additional options
+	ID               string            // Docker network ID
+	Bridge           string            // OVS bridge name
+	TenantID         string            // Tenant identifier
+	VLAN             string            // VLAN tag
+	MTU              string            // Maximum transmission unit
+	OVNSwitch        string            // OVN logical switch name
+	OVNRouter        string            // OVN logical router name
+	MirrorPorts      string            // Comma-separated list of ports to mirror
+	MirrorDest       string            // Destination port for mirrored traffic
+	EnableSNATOnHost bool              // SNAT endpoint egress to an infra-vnet address on the host
+	InfraVNetCIDR    string            // Pool to allocate per-tenant SNAT addresses from
+	Mode             string            // Driver mode: "ovs" (default), "vlan", or "trunk"
+	Parent           string            // Host NIC to create tagged sub-interfaces on, for mode=vlan/trunk
+	IPv4Data         *network.IPAMData // IPv4 configuration
+	IPv6Data         *network.IPAMData // IPv6 configuration
+	Options          map[string]string // Additional options
 }
 
 // Endpoint represents a network endpoint (container connection)
 type Endpoint struct {
-	ID          string            // Docker endpoint ID
-	NetworkID   string            // Associated network ID
-	Network     *Network          // Network configuration
-	MacAddress  string            // MAC address
-	IPv4Address string            // IPv4 address with prefix
-	IPv6Address string            // IPv6 address with prefix
-	PortName    string            // OVS port name
-	VethName    string            // Veth interface name
-	Options     map[string]string // Additional options
+	ID                  string               // Docker endpoint ID
+	NetworkID           string               // Associated network ID
+	Network             *Network             // Network configuration
+	MacAddress          string               // MAC address
+	IPv4Address         string               // IPv4 address with prefix
+	IPv6Address         string               // IPv6 address with prefix
+	PortName            string               // OVS port name
+	VethName            string               // Veth interface name
+	SandboxKey          string               // Container's netns path, set in Join; identifies its sandbox across a plugin restart
+	SNATAddress         string               // Infra-vnet address this endpoint's egress is SNATed to, if enabled
+	EIPID               string               // ID of this endpoint's elastic IP allocation in the store, if "ovn.eip" was requested
+	PortBindings        []PortBinding        // Published container ports, programmed in ProgramExternalConnectivity
+	SecondaryInterfaces []SecondaryInterface // Additional NICs requested via the "secondary_networks" option, joined alongside the primary eth0
+	Options             map[string]string    // Additional options
+}
+
+// SecondaryInterface records one additional NIC a multi-homed endpoint was
+// joined to, beyond the primary network's eth0 - parsed from the
+// "secondary_networks" option at CreateEndpoint time and filled in by Join,
+// which creates a veth pair and OVN logical port per entry the same way it
+// does for the primary, then moves each into the sandbox as IfName
+// (Multus-style net1, net2, ...) instead of eth0.
+type SecondaryInterface struct {
+	IfName      string // "net1", "net2", ... in the order requested
+	NetworkID   string // Docker network ID this interface attaches to
+	MacAddress  string
+	IPv4Address string
+	VethName    string // Host-side veth name, set by Join
+	PortName    string // OVS port name (the veth's container-side peer), set by Join
+	OVNPort     string // OVN logical port name, set by Join if the network is OVN-backed
+}
+
+// PortBinding records one published container port ("-p hostPort:containerPort/proto"),
+// mirroring store.PortBinding for the in-memory Endpoint the same way every
+// other field here duplicates rather than imports the store's persisted shape.
+type PortBinding struct {
+	Proto         string // "tcp", "udp", or "sctp"
+	ContainerPort uint16
+	HostIP        string
+	HostPort      uint16
+	HostPortEnd   uint16 // equal to HostPort for a single-port binding
+	LBUUID        string // OVN Load_Balancer row backing this VIP
 }
@@ -0,0 +1,78 @@
+//go:build linux
+
+// Package vlan manages plain 802.1Q VLAN sub-interfaces, for the "vlan" and
+// "trunk" network modes where a container is attached directly to a tagged
+// sub-interface of a host NIC instead of an OVS bridge, mirroring how the
+// upstream ipvlan/macvlan drivers manage their own tagged parents.
+package vlan
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+)
+
+// aliasPrefix marks interfaces this package created as plugin-managed.
+// DeleteVlanSubinterface does not check it: it tells a sub-interface apart
+// from a physical NIC by link type and ParentIndex.
+const aliasPrefix = "ovs-plugin-"
+
+// CreateVlanSubinterface creates a tagged 802.1Q sub-interface named `name`
+// on top of `parent` for VLAN `vlanID`, aliases it so it's identifiable as
+// plugin-managed, and brings it up.
+func CreateVlanSubinterface(parent, name string, vlanID int) error {
+	parentLink, err := netlink.LinkByName(parent)
+	if err != nil {
+		return fmt.Errorf("failed to find parent link %s: %w", parent, err)
+	}
+
+	if existing, err := netlink.LinkByName(name); err == nil {
+		if err := netlink.LinkDel(existing); err != nil {
+			return fmt.Errorf("failed to remove existing link %s: %w", name, err)
+		}
+	}
+
+	vlanLink := &netlink.Vlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        name,
+			ParentIndex: parentLink.Attrs().Index,
+			Alias:       aliasPrefix + name,
+		},
+		VlanId: vlanID,
+	}
+
+	if err := netlink.LinkAdd(vlanLink); err != nil {
+		return fmt.Errorf("failed to create VLAN sub-interface %s on %s (vlan %d): %w", name, parent, vlanID, err)
+	}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to find newly created link %s: %w", name, err)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		return fmt.Errorf("failed to bring up VLAN sub-interface %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteVlanSubinterface removes a VLAN sub-interface previously created with
+// CreateVlanSubinterface. It refuses to touch a link whose ParentIndex is 0,
+// since that means it isn't a VLAN sub-interface at all (i.e. it's someone's
+// physical NIC, never safe to delete here).
+func DeleteVlanSubinterface(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return nil // Idempotent: already gone
+	}
+
+	vlanLink, ok := link.(*netlink.Vlan)
+	if !ok || vlanLink.Attrs().ParentIndex == 0 {
+		return fmt.Errorf("refusing to delete %s: not a plugin-managed VLAN sub-interface", name)
+	}
+
+	if err := netlink.LinkDel(link); err != nil {
+		return fmt.Errorf("failed to delete VLAN sub-interface %s: %w", name, err)
+	}
+	return nil
+}
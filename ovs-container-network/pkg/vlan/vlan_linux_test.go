@@ -0,0 +1,13 @@
+//go:build linux
+
+package vlan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteVlanSubinterfaceIdempotentWhenMissing(t *testing.T) {
+	assert.NoError(t, DeleteVlanSubinterface("ovstest-missing-vlan0"))
+}
@@ -0,0 +1,19 @@
+//go:build !linux
+
+package vlan
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// CreateVlanSubinterface is unsupported outside Linux: 802.1Q sub-interfaces
+// here are created with netlink, which only exists on Linux.
+func CreateVlanSubinterface(parent, name string, vlanID int) error {
+	return fmt.Errorf("vlan: sub-interfaces are not supported on GOOS=%s", runtime.GOOS)
+}
+
+// DeleteVlanSubinterface is unsupported outside Linux; see CreateVlanSubinterface.
+func DeleteVlanSubinterface(name string) error {
+	return fmt.Errorf("vlan: sub-interfaces are not supported on GOOS=%s", runtime.GOOS)
+}
@@ -16,10 +16,10 @@ import (
 )
 
 const (
-	pluginName      = "ovs-container-network:latest"
-	testNetPrefix   = "itest-net"
-	testContPrefix  = "itest-cont"
-	testTimeout     = 30 * time.Second
+	pluginName     = "ovs-container-network:latest"
+	testNetPrefix  = "itest-net"
+	testContPrefix = "itest-cont"
+	testTimeout    = 30 * time.Second
 )
 
 var dockerClient *client.Client
@@ -367,6 +367,84 @@ func TestPersistentState(t *testing.T) {
 	}
 }
 
+func TestLiveRestoreAfterPluginRestart(t *testing.T) {
+	ctx := context.Background()
+	networkName := fmt.Sprintf("%s-restore", testNetPrefix)
+
+	netConfig := types.NetworkCreate{
+		Driver: pluginName,
+		IPAM: &network.IPAM{
+			Config: []network.IPAMConfig{{Subnet: "10.206.0.0/24"}},
+		},
+	}
+
+	netResp, err := dockerClient.NetworkCreate(ctx, networkName, netConfig)
+	if err != nil {
+		t.Fatalf("Failed to create network: %v", err)
+	}
+	defer dockerClient.NetworkRemove(ctx, netResp.ID)
+
+	cont1, err := createTestContainer(ctx, fmt.Sprintf("%s-restore1", testContPrefix), networkName)
+	if err != nil {
+		t.Fatalf("Failed to create container 1: %v", err)
+	}
+	defer dockerClient.ContainerRemove(ctx, cont1, types.ContainerRemoveOptions{Force: true})
+
+	cont2, err := createTestContainer(ctx, fmt.Sprintf("%s-restore2", testContPrefix), networkName)
+	if err != nil {
+		t.Fatalf("Failed to create container 2: %v", err)
+	}
+	defer dockerClient.ContainerRemove(ctx, cont2, types.ContainerRemoveOptions{Force: true})
+
+	dockerClient.ContainerStart(ctx, cont1, types.ContainerStartOptions{})
+	dockerClient.ContainerStart(ctx, cont2, types.ContainerStartOptions{})
+	time.Sleep(3 * time.Second)
+
+	cont2Info, err := dockerClient.ContainerInspect(ctx, cont2)
+	if err != nil {
+		t.Fatalf("Failed to inspect container 2: %v", err)
+	}
+	cont2IP := cont2Info.NetworkSettings.Networks[networkName].IPAddress
+	if cont2IP == "" {
+		t.Fatal("Container 2 did not get an IP address")
+	}
+
+	// Kill the plugin mid-flight (containers stay up, veths/ports untouched)
+	cmd := exec.Command("docker", "plugin", "disable", "-f", pluginName)
+	cmd.Run()
+	time.Sleep(2 * time.Second)
+
+	cmd = exec.Command("docker", "plugin", "enable", pluginName)
+	cmd.Run()
+	time.Sleep(3 * time.Second)
+
+	// Traffic should resume without recreating the containers: the restarted
+	// plugin should have reconciled its persisted state against live OVS/netlink
+	// state in recoverState rather than leaving the existing ports orphaned.
+	execConfig := types.ExecConfig{
+		Cmd:          []string{"ping", "-c", "2", "-W", "2", cont2IP},
+		AttachStdout: true,
+		AttachStderr: true,
+	}
+
+	execResp, err := dockerClient.ContainerExecCreate(ctx, cont1, execConfig)
+	if err != nil {
+		t.Fatalf("Failed to create exec: %v", err)
+	}
+	if err := dockerClient.ContainerExecStart(ctx, execResp.ID, types.ExecStartCheck{}); err != nil {
+		t.Fatalf("Failed to start exec: %v", err)
+	}
+	time.Sleep(3 * time.Second)
+
+	execInspect, err := dockerClient.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		t.Fatalf("Failed to inspect exec: %v", err)
+	}
+	if execInspect.ExitCode != 0 {
+		t.Errorf("Ping failed after plugin restart with exit code %d", execInspect.ExitCode)
+	}
+}
+
 func TestOVNIntegration(t *testing.T) {
 	ctx := context.Background()
 	networkName := fmt.Sprintf("%s-ovn", testNetPrefix)
@@ -438,4 +516,4 @@ func createTestContainer(ctx context.Context, name, networkName string) (string,
 	}
 
 	return resp.ID, nil
-}
\ No newline at end of file
+}